@@ -0,0 +1,64 @@
+package main
+
+import "sync"
+
+// PrimeResult is the classification of a single input number.
+type PrimeResult struct {
+	Number uint64
+	Prime  bool
+}
+
+// ClassifyPrimes tests every number received from numbers with IsPrime64 on a
+// pool of workers goroutines. Results are delivered in input order. A number
+// is only read once an earlier one has a free slot in the pending queue, so at
+// most workers+2 numbers are read but not yet delivered (the queued ones, the
+// one waiting to be delivered and the one being dispatched), and unbounded
+// input streams run in constant memory. The returned channel is closed after
+// numbers is closed and every result has been delivered.
+func ClassifyPrimes(numbers <-chan uint64, workers int) <-chan PrimeResult {
+	if workers < 1 {
+		workers = 1
+	}
+
+	type job struct {
+		number uint64
+		out    chan PrimeResult
+	}
+
+	jobs := make(chan job)
+	pending := make(chan chan PrimeResult, workers)
+	results := make(chan PrimeResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				j.out <- PrimeResult{Number: j.number, Prime: IsPrime64(j.number)}
+			}
+		}()
+	}
+
+	// Dispatch jobs and queue each job's result slot in input order.
+	go func() {
+		for n := range numbers {
+			out := make(chan PrimeResult, 1)
+			jobs <- job{number: n, out: out}
+			pending <- out
+		}
+		close(jobs)
+		close(pending)
+	}()
+
+	// Collect results in the order their slots were queued.
+	go func() {
+		for out := range pending {
+			results <- <-out
+		}
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
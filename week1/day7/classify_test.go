@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClassifyPrimes(t *testing.T) {
+	for _, workers := range []int{0, 1, 4, 16} {
+		numbers := make(chan uint64)
+		go func() {
+			for n := uint64(0); n <= 10000; n++ {
+				numbers <- n
+			}
+			close(numbers)
+		}()
+
+		expected := uint64(0)
+		for result := range ClassifyPrimes(numbers, workers) {
+			if result.Number != expected {
+				t.Fatalf("workers=%d: got result for %d; want %d", workers, result.Number, expected)
+			}
+			if result.Prime != IsPrime64(expected) {
+				t.Errorf("workers=%d: Prime(%d) = %v; want %v", workers, expected, result.Prime, !result.Prime)
+			}
+			expected++
+		}
+		if expected != 10001 {
+			t.Errorf("workers=%d: got %d results; want 10001", workers, expected)
+		}
+	}
+}
+
+func TestClassifyPrimesBounded(t *testing.T) {
+	const workers = 4
+	numbers := make(chan uint64)
+	read := make(chan int)
+	go func() {
+		n := 0
+		for {
+			select {
+			case numbers <- uint64(n):
+				n++
+			case read <- n:
+				return
+			}
+		}
+	}()
+
+	results := ClassifyPrimes(numbers, workers)
+	<-results // deliver one result, then stop reading
+	time.Sleep(50 * time.Millisecond)
+	if n := <-read; n > 1+workers+2 {
+		t.Errorf("%d numbers were read for 1 delivered result; want at most %d", n, 1+workers+2)
+	}
+}
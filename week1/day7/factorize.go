@@ -0,0 +1,133 @@
+package main
+
+import "sort"
+
+// trialDivisionLimit bounds the small factors removed by trial division
+// before falling back to Pollard's rho.
+const trialDivisionLimit = 1000
+
+// Factorize returns the prime factorization of n as a map from prime to
+// exponent. Values below 2 have no prime factors and yield an empty map.
+func Factorize(n uint64) map[uint64]int {
+	factors := make(map[uint64]int)
+	if n < 2 {
+		return factors
+	}
+
+	for n%2 == 0 {
+		factors[2]++
+		n /= 2
+	}
+	for p := uint64(3); p <= trialDivisionLimit && p*p <= n; p += 2 {
+		for n%p == 0 {
+			factors[p]++
+			n /= p
+		}
+	}
+
+	if n > 1 {
+		factorizeLarge(n, factors)
+	}
+	return factors
+}
+
+// factorizeLarge splits n, which has no factors below trialDivisionLimit,
+// with Pollard's rho until only primes remain.
+func factorizeLarge(n uint64, factors map[uint64]int) {
+	if n == 1 {
+		return
+	}
+	if IsPrime64(n) {
+		factors[n]++
+		return
+	}
+
+	d := pollardRho(n)
+	factorizeLarge(d, factors)
+	factorizeLarge(n/d, factors)
+}
+
+// pollardRho returns a non-trivial divisor of the odd composite n using
+// Brent's variant of Pollard's rho.
+func pollardRho(n uint64) uint64 {
+	for c := uint64(1); ; c++ {
+		f := func(x uint64) uint64 { return (mulMod(x, x, n) + c) % n }
+
+		x, y, d := uint64(2), uint64(2), uint64(1)
+		for power, lam := 1, 0; d == 1; {
+			if power == lam {
+				x = y
+				power *= 2
+				lam = 0
+			}
+			y = f(y)
+			lam++
+			d = GCD(diff(x, y), n)
+		}
+
+		if d != n {
+			return d
+		}
+	}
+}
+
+// Divisors returns every positive divisor of n in increasing order.
+// Divisors(0) returns nil.
+func Divisors(n uint64) []uint64 {
+	if n == 0 {
+		return nil
+	}
+
+	divisors := []uint64{1}
+	for p, e := range Factorize(n) {
+		count := len(divisors)
+		pk := uint64(1)
+		for k := 1; k <= e; k++ {
+			pk *= p
+			for _, d := range divisors[:count] {
+				divisors = append(divisors, d*pk)
+			}
+		}
+	}
+
+	sort.Slice(divisors, func(i, j int) bool { return divisors[i] < divisors[j] })
+	return divisors
+}
+
+// Totient returns Euler's totient φ(n), the count of integers in [1, n]
+// coprime to n. Totient(0) is 0.
+func Totient(n uint64) uint64 {
+	if n == 0 {
+		return 0
+	}
+
+	result := n
+	for p := range Factorize(n) {
+		result = result / p * (p - 1)
+	}
+	return result
+}
+
+// GCD returns the greatest common divisor of a and b.
+func GCD(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// LCM returns the least common multiple of a and b, or 0 if either is 0.
+// The result wraps if it does not fit in a uint64.
+func LCM(a, b uint64) uint64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return a / GCD(a, b) * b
+}
+
+func diff(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFactorize(t *testing.T) {
+	tests := []struct {
+		input    uint64
+		expected map[uint64]int
+	}{
+		{0, map[uint64]int{}},
+		{1, map[uint64]int{}},
+		{2, map[uint64]int{2: 1}},
+		{12, map[uint64]int{2: 2, 3: 1}},
+		{97, map[uint64]int{97: 1}},
+		{360, map[uint64]int{2: 3, 3: 2, 5: 1}},
+		{1 << 63, map[uint64]int{2: 63}},
+		{600851475143, map[uint64]int{71: 1, 839: 1, 1471: 1, 6857: 1}},
+		{4294967297, map[uint64]int{641: 1, 6700417: 1}},
+		{9223372036854775807, map[uint64]int{7: 2, 73: 1, 127: 1, 337: 1, 92737: 1, 649657: 1}},
+		{18446744073709551615, map[uint64]int{3: 1, 5: 1, 17: 1, 257: 1, 641: 1, 65537: 1, 6700417: 1}},
+		{18446744030759878681, map[uint64]int{4294967291: 2}},
+		{1000000016000000063, map[uint64]int{1000000007: 1, 1000000009: 1}},
+	}
+
+	for _, test := range tests {
+		if result := Factorize(test.input); fmt.Sprint(result) != fmt.Sprint(test.expected) {
+			t.Errorf("Factorize(%d) = %v; want %v", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestFactorizeProduct(t *testing.T) {
+	for n := uint64(2); n <= 10000; n++ {
+		product := uint64(1)
+		for p, e := range Factorize(n) {
+			if !IsPrime64(p) {
+				t.Fatalf("Factorize(%d) has non-prime factor %d", n, p)
+			}
+			for i := 0; i < e; i++ {
+				product *= p
+			}
+		}
+		if product != n {
+			t.Fatalf("Factorize(%d) multiplies back to %d", n, product)
+		}
+	}
+}
+
+func TestDivisors(t *testing.T) {
+	tests := []struct {
+		input    uint64
+		expected []uint64
+	}{
+		{0, nil},
+		{1, []uint64{1}},
+		{13, []uint64{1, 13}},
+		{28, []uint64{1, 2, 4, 7, 14, 28}},
+		{36, []uint64{1, 2, 3, 4, 6, 9, 12, 18, 36}},
+	}
+
+	for _, test := range tests {
+		if result := Divisors(test.input); fmt.Sprint(result) != fmt.Sprint(test.expected) {
+			t.Errorf("Divisors(%d) = %v; want %v", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestTotient(t *testing.T) {
+	tests := []struct {
+		input    uint64
+		expected uint64
+	}{
+		{0, 0},
+		{1, 1},
+		{9, 6},
+		{10, 4},
+		{97, 96},
+		{360, 96},
+		{4294967297, 4288266240},
+	}
+
+	for _, test := range tests {
+		if result := Totient(test.input); result != test.expected {
+			t.Errorf("Totient(%d) = %d; want %d", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestGCDAndLCM(t *testing.T) {
+	tests := []struct {
+		a, b     uint64
+		gcd, lcm uint64
+	}{
+		{0, 0, 0, 0},
+		{0, 5, 5, 0},
+		{12, 18, 6, 36},
+		{17, 5, 1, 85},
+		{1 << 40, 1 << 20, 1 << 20, 1 << 40},
+	}
+
+	for _, test := range tests {
+		if result := GCD(test.a, test.b); result != test.gcd {
+			t.Errorf("GCD(%d, %d) = %d; want %d", test.a, test.b, result, test.gcd)
+		}
+		if result := LCM(test.a, test.b); result != test.lcm {
+			t.Errorf("LCM(%d, %d) = %d; want %d", test.a, test.b, result, test.lcm)
+		}
+	}
+}
+
+func BenchmarkFactorize(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Factorize(1000000016000000063)
+	}
+}
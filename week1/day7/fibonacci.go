@@ -1,5 +1,19 @@
 package main
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+var (
+	ErrNegativeInput = errors.New("negative input")
+	ErrOverflow      = errors.New("result overflows int")
+)
+
 func Fibonacci(i int) int {
 	if i == 0 {
 		return 0
@@ -16,3 +30,88 @@ func Fibonacci(i int) int {
 	return b
 
 }
+
+// FibonacciChecked returns F(n), or an error wrapping ErrNegativeInput or
+// ErrOverflow instead of a wrapped-around value.
+func FibonacciChecked(n int) (int, error) {
+	if n < 0 {
+		return 0, fmt.Errorf("fibonacci(%d): %w", n, ErrNegativeInput)
+	}
+	if n == 0 {
+		return 0, nil
+	}
+
+	a, b := 0, 1
+
+	for i := 2; i <= n; i++ {
+		if b > math.MaxInt-a {
+			return 0, fmt.Errorf("fibonacci(%d): %w", n, ErrOverflow)
+		}
+		a, b = b, a+b
+	}
+	return b, nil
+}
+
+// FibonacciBig returns F(n) as a big.Int using the fast doubling identities:
+//
+//	F(2k)   = F(k) * (2*F(k+1) - F(k))
+//	F(2k+1) = F(k+1)^2 + F(k)^2
+//
+// so it needs O(log n) big-number multiplications instead of n additions.
+func FibonacciBig(n uint64) *big.Int {
+	a, _ := fibonacciPair(n)
+	return a
+}
+
+// fibonacciPair returns F(n) and F(n+1).
+func fibonacciPair(n uint64) (*big.Int, *big.Int) {
+	a := big.NewInt(0) // F(k)
+	b := big.NewInt(1) // F(k+1)
+	t := new(big.Int)
+
+	for i := bits.Len64(n) - 1; i >= 0; i-- {
+		// c = F(2k) = F(k) * (2*F(k+1) - F(k))
+		c := new(big.Int).Lsh(b, 1)
+		c.Sub(c, a)
+		c.Mul(c, a)
+
+		// d = F(2k+1) = F(k)^2 + F(k+1)^2
+		d := new(big.Int).Mul(a, a)
+		d.Add(d, t.Mul(b, b))
+
+		if n>>uint(i)&1 == 0 {
+			a, b = c, d
+		} else {
+			a, b = d, c.Add(c, d)
+		}
+	}
+	return a, b
+}
+
+// FibonacciSeq streams F(from) through F(to) inclusive on the returned channel.
+// The channel is closed once the range is exhausted or ctx is cancelled.
+func FibonacciSeq(ctx context.Context, from, to uint64) <-chan *big.Int {
+	ch := make(chan *big.Int)
+
+	go func() {
+		defer close(ch)
+		if from > to {
+			return
+		}
+
+		a, b := fibonacciPair(from)
+		for n := from; ; n++ {
+			select {
+			case ch <- new(big.Int).Set(a):
+			case <-ctx.Done():
+				return
+			}
+			if n == to {
+				return
+			}
+			a, b = b, a.Add(a, b)
+		}
+	}()
+
+	return ch
+}
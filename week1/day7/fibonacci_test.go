@@ -1,11 +1,18 @@
 package main
 
-import "testing"
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
 
 func TestFibonacci(t *testing.T) {
 	test := []struct {
 		input    int
-		expected int
+		expected int64 // F(50) and up do not fit a 32-bit int
 	}{
 		{0, 0},
 		{1, 1},
@@ -18,11 +25,110 @@ func TestFibonacci(t *testing.T) {
 		{8, 21},
 		{9, 34},
 		{10, 55},
+		{50, 12586269025},
+		{92, 7540113804746346429},
 	}
 
 	for _, test := range test {
-		if result := Fibonacci(test.input); result != test.expected {
-			t.Errorf("Fibonacci(%d) = %d; want %d", test.input, result, test.expected)
+		if test.expected <= math.MaxInt {
+			if result := Fibonacci(test.input); int64(result) != test.expected {
+				t.Errorf("Fibonacci(%d) = %d; want %d", test.input, result, test.expected)
+			}
+		}
+		if result := FibonacciBig(uint64(test.input)); !result.IsInt64() || result.Int64() != test.expected {
+			t.Errorf("FibonacciBig(%d) = %s; want %d", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestFibonacciBig(t *testing.T) {
+	tests := []struct {
+		input  uint64
+		digits int
+		sha256 string
+	}{
+		{93, 20, "995801a3743fb3b3864b4b63d56eb7fcd49a8daf7024ff82f76d8485064a4346"},
+		{100, 21, "9f3cd0550139d594df1a95c59b7cac2469e3f594ead276485b23a5016f09e830"},
+		{200, 42, "94ec0682569a1850d42c4855b75d428d0f98096239cce3a47baa9b5a217a6a0d"},
+		{1000, 209, "30ad6e2a0cbbd0a636a53ce8df4f703ece426736cf4777519f1c5cffbea2e220"},
+		{10000, 2090, "e9c83559a05641cfd86d6c192c53fdfda87b8e53470b63dc19d1d0d7526e987a"},
+		{100000, 20899, "9fe22f691a91170da9006226d479ad986b2f92021b7045ecfb0a5091b641b802"},
+	}
+
+	for _, test := range tests {
+		s := FibonacciBig(test.input).String()
+		if len(s) != test.digits {
+			t.Errorf("FibonacciBig(%d) has %d digits; want %d", test.input, len(s), test.digits)
+		}
+		if sum := fmt.Sprintf("%x", sha256.Sum256([]byte(s))); sum != test.sha256 {
+			t.Errorf("FibonacciBig(%d) digest = %s; want %s", test.input, sum, test.sha256)
+		}
+	}
+}
+
+func TestFibonacciSeq(t *testing.T) {
+	tests := []struct {
+		from, to uint64
+		expected []string
+	}{
+		{0, 5, []string{"0", "1", "1", "2", "3", "5"}},
+		{10, 12, []string{"55", "89", "144"}},
+		{100, 101, []string{"354224848179261915075", "573147844013817084101"}},
+		{7, 7, []string{"13"}},
+		{8, 7, nil},
+	}
+
+	for _, test := range tests {
+		var result []string
+		for v := range FibonacciSeq(context.Background(), test.from, test.to) {
+			result = append(result, v.String())
+		}
+		if fmt.Sprint(result) != fmt.Sprint(test.expected) {
+			t.Errorf("FibonacciSeq(%d, %d) = %v; want %v", test.from, test.to, result, test.expected)
+		}
+	}
+}
+
+func TestFibonacciSeqCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := FibonacciSeq(ctx, 0, 1<<62)
+
+	<-ch
+	cancel()
+	for range ch {
+	}
+}
+
+func BenchmarkFibonacciBig(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		FibonacciBig(100000)
+	}
+}
+
+func TestFibonacciChecked(t *testing.T) {
+	tests := []struct {
+		input    int
+		expected int64
+		err      error
+	}{
+		{-1, 0, ErrNegativeInput},
+		{0, 0, nil},
+		{1, 1, nil},
+		{10, 55, nil},
+		{46, 1836311903, nil}, // the largest that fits a 32-bit int
+		{92, 7540113804746346429, nil},
+		{93, 0, ErrOverflow},
+		{1000, 0, ErrOverflow},
+	}
+
+	for _, test := range tests {
+		if test.expected > math.MaxInt {
+			// On 32-bit platforms these overflow as well.
+			test.expected, test.err = 0, ErrOverflow
+		}
+		result, err := FibonacciChecked(test.input)
+		if !errors.Is(err, test.err) || int64(result) != test.expected {
+			t.Errorf("FibonacciChecked(%d) = %d, %v; want %d, %v", test.input, result, err, test.expected, test.err)
 		}
 	}
 }
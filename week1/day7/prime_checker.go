@@ -1,17 +1,25 @@
 package main
 
 import (
+	"bufio"
+	"errors"
+	"flag"
 	"fmt"
 	"math"
+	"math/bits"
+	"os"
+	"runtime"
+	"strconv"
+	"time"
 )
 
 func IsPrime(n int) bool {
-	if n <= 2 {
+	if n < 2 {
 		return false
 	}
 
 	if n%2 == 0 {
-		return false
+		return n == 2
 	}
 
 	limit := int(math.Sqrt(float64(n)))
@@ -25,10 +33,155 @@ func IsPrime(n int) bool {
 	return true
 }
 
+// millerRabinWitnesses are the primes up to 37, a sufficient witness set
+// that makes Miller-Rabin deterministic for every n < 2^64.
+var millerRabinWitnesses = []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+// IsPrime64 reports whether n is prime using deterministic Miller-Rabin, so it
+// stays fast across the full uint64 range.
+func IsPrime64(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	for _, p := range millerRabinWitnesses {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+
+	// n-1 = d * 2^s with d odd
+	d := n - 1
+	s := bits.TrailingZeros64(d)
+	d >>= uint(s)
+
+	for _, a := range millerRabinWitnesses {
+		x := powMod(a, d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+		composite := true
+		for r := 1; r < s; r++ {
+			x = mulMod(x, x, n)
+			if x == n-1 {
+				composite = false
+				break
+			}
+		}
+		if composite {
+			return false
+		}
+	}
+	return true
+}
+
+// mulMod returns a*b mod m without overflowing, using a 128-bit product.
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, rem := bits.Div64(hi%m, lo, m)
+	return rem
+}
+
+// powMod returns base^exp mod m by square-and-multiply.
+func powMod(base, exp, m uint64) uint64 {
+	result := uint64(1)
+	base %= m
+	for exp > 0 {
+		if exp&1 == 1 {
+			result = mulMod(result, base, m)
+		}
+		base = mulMod(base, base, m)
+		exp >>= 1
+	}
+	return result
+}
+
 func main() {
-	numbers := []int{1, 2, 3, 4, 11, 12, 13, 14, 21, 22, 23}
+	workers := flag.Int("workers", runtime.NumCPU(), "number of goroutines classifying numbers")
+	fib := flag.Bool("fib", false, "treat the input as Fibonacci indexes instead of primality candidates")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-workers N] [-fib] [file]\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "Reads whitespace-separated numbers from file, or stdin if omitted.")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	*workers = max(*workers, 1) // ClassifyPrimes uses at least one worker
+
+	input := os.Stdin
+	if flag.NArg() > 0 {
+		file, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fmt.Println("Failed to open the file:", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	scanner := bufio.NewScanner(input)
+	scanner.Split(bufio.ScanWords)
+
+	if *fib {
+		printFibonacci(scanner)
+	} else {
+		printPrimes(scanner, *workers)
+	}
+
+	if err := scanner.Err(); err != nil {
+		fmt.Println("Error while reading input:", err)
+		os.Exit(1)
+	}
+}
+
+// printPrimes classifies every number read by scanner and prints the results
+// in input order, followed by a summary.
+func printPrimes(scanner *bufio.Scanner, workers int) {
+	start := time.Now()
 
-	for _, num := range numbers {
-		fmt.Printf("%d is Prime numbers %v\n", num, IsPrime(num))
+	numbers := make(chan uint64)
+	go func() {
+		defer close(numbers)
+		for scanner.Scan() {
+			n, err := strconv.ParseUint(scanner.Text(), 10, 64)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Skipping invalid number %q\n", scanner.Text())
+				continue
+			}
+			numbers <- n
+		}
+	}()
+
+	total, primes := 0, 0
+	for result := range ClassifyPrimes(numbers, workers) {
+		total++
+		if result.Prime {
+			primes++
+		}
+		fmt.Printf("%d is Prime numbers %v\n", result.Number, result.Prime)
+	}
+
+	fmt.Printf("%d of %d numbers are prime (%d workers, elapsed %s)\n", primes, total, workers, time.Since(start))
+}
+
+// printFibonacci prints F(n) for every index read by scanner, reporting
+// negative and overflowing indexes instead of printing a wrapped value.
+func printFibonacci(scanner *bufio.Scanner) {
+	for scanner.Scan() {
+		n, err := strconv.Atoi(scanner.Text())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping invalid number %q\n", scanner.Text())
+			continue
+		}
+
+		result, err := FibonacciChecked(n)
+		switch {
+		case errors.Is(err, ErrNegativeInput):
+			fmt.Printf("Fibonacci(%d): input must not be negative\n", n)
+		case errors.Is(err, ErrOverflow):
+			fmt.Printf("Fibonacci(%d): result is too large for int, use FibonacciBig\n", n)
+		case err != nil:
+			fmt.Println("Error:", err)
+		default:
+			fmt.Printf("Fibonacci(%d) = %d\n", n, result)
+		}
 	}
 }
@@ -8,7 +8,7 @@ func TestIsPrime(t *testing.T) {
 		expected bool
 	}{
 		{1, false},
-		{2, false},
+		{2, true},
 		{3, true},
 		{4, false},
 		{11, true},
@@ -27,3 +27,50 @@ func TestIsPrime(t *testing.T) {
 	}
 
 }
+
+func TestIsPrime64(t *testing.T) {
+	tests := []struct {
+		input    uint64
+		expected bool
+	}{
+		{0, false},
+		{1, false},
+		{2, true},
+		{37, true},
+		{41, true},
+		{561, false},                  // Carmichael number
+		{3215031751, false},           // strong pseudoprime to bases 2, 3, 5, 7
+		{3825123056546413051, false},  // strong pseudoprime to bases 2..23
+		{4294967291, true},            // largest prime below 2^32
+		{4294967297, false},           // 2^32 + 1 = 641 * 6700417
+		{1000000007, true},            // common modulus
+		{9223372036854775783, true},   // largest prime below 2^63
+		{9223372036854775807, false},  // 2^63 - 1 = 7^2 * 73 * ...
+		{18446744073709551557, true},  // largest prime below 2^64
+		{18446744073709551615, false}, // 2^64 - 1
+		{18446744030759878681, false}, // 4294967291^2
+	}
+
+	for _, test := range tests {
+		if result := IsPrime64(test.input); result != test.expected {
+			t.Errorf("IsPrime64(%d) = %v; want %v", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestIsPrime64AgainstSieve(t *testing.T) {
+	const limit = 1000000
+	table := NewPrimeTable(0, limit)
+
+	for n := 0; n <= limit; n++ {
+		if result, expected := IsPrime64(uint64(n)), table.IsPrime(n); result != expected {
+			t.Fatalf("IsPrime64(%d) = %v; sieve says %v", n, result, expected)
+		}
+	}
+}
+
+func BenchmarkIsPrime64(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		IsPrime64(18446744073709551557)
+	}
+}
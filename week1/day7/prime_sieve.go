@@ -0,0 +1,141 @@
+package main
+
+import "math"
+
+// sieveSegmentSize is the number of values sieved at a time; it keeps the
+// working set of a segment small enough to stay in the CPU cache.
+const sieveSegmentSize = 1 << 15
+
+// PrimesUpTo returns all primes p with p <= n in increasing order.
+func PrimesUpTo(n int) []int {
+	return PrimesInRange(2, n)
+}
+
+// PrimesInRange returns all primes p with lo <= p <= hi in increasing order,
+// using a segmented Sieve of Eratosthenes.
+func PrimesInRange(lo, hi int) []int {
+	var primes []int
+	sieveRange(lo, hi, func(start int, composite []bool) {
+		for i, c := range composite {
+			if !c {
+				primes = append(primes, start+i)
+			}
+		}
+	})
+	return primes
+}
+
+// PrimeTable caches the primality of every integer in [lo, hi] so that
+// repeated lookups in that range cost O(1).
+type PrimeTable struct {
+	lo, hi int
+	bits   []uint64
+}
+
+// NewPrimeTable sieves [lo, hi] and returns a table for it.
+func NewPrimeTable(lo, hi int) *PrimeTable {
+	t := &PrimeTable{lo: lo, hi: hi}
+	if hi >= lo {
+		t.bits = make([]uint64, (hi-lo)/64+1)
+	}
+	sieveRange(lo, hi, func(start int, composite []bool) {
+		for i, c := range composite {
+			if !c {
+				off := start + i - lo
+				t.bits[off/64] |= 1 << (off % 64)
+			}
+		}
+	})
+	return t
+}
+
+// Contains reports whether n lies within the cached range.
+func (t *PrimeTable) Contains(n int) bool {
+	return n >= t.lo && n <= t.hi
+}
+
+// IsPrime reports whether n is prime. Values outside the cached range fall
+// back to trial division.
+func (t *PrimeTable) IsPrime(n int) bool {
+	if !t.Contains(n) {
+		return IsPrime(n)
+	}
+	off := n - t.lo
+	return t.bits[off/64]&(1<<(off%64)) != 0
+}
+
+// sieveRange calls fn for consecutive segments covering [max(lo, 2), hi].
+// composite[i] reports whether start+i is composite; the slice is reused
+// between calls.
+func sieveRange(lo, hi int, fn func(start int, composite []bool)) {
+	if lo < 2 {
+		lo = 2
+	}
+	if hi < lo {
+		return
+	}
+
+	base := simpleSieve(isqrt(hi))
+	segment := make([]bool, min(sieveSegmentSize, hi-lo+1))
+
+	for start := lo; ; {
+		end := hi
+		if hi-start >= sieveSegmentSize {
+			end = start + sieveSegmentSize - 1
+		}
+
+		composite := segment[:end-start+1]
+		clear(composite)
+		for _, p := range base {
+			if p*p > end {
+				break
+			}
+			first := max(p*p, start+(p-start%p)%p)
+			// m >= first stops the loop if m += p wraps around near math.MaxInt.
+			for m := first; m <= end && m >= first; m += p {
+				composite[m-start] = true
+			}
+		}
+		fn(start, composite)
+
+		if end == hi {
+			return
+		}
+		start = end + 1
+	}
+}
+
+// simpleSieve returns all primes up to n with a plain Sieve of Eratosthenes.
+func simpleSieve(n int) []int {
+	if n < 2 {
+		return nil
+	}
+
+	composite := make([]bool, n+1)
+	var primes []int
+	for i := 2; i <= n; i++ {
+		if composite[i] {
+			continue
+		}
+		primes = append(primes, i)
+		for m := i * i; m <= n; m += i {
+			composite[m] = true
+		}
+	}
+	return primes
+}
+
+// isqrt returns the largest r with r*r <= n.
+func isqrt(n int) int {
+	if n < 0 {
+		return 0
+	}
+	r := int(math.Sqrt(float64(n)))
+	for r*r > n {
+		r--
+	}
+	for (r+1)*(r+1) <= n {
+		r++
+	}
+	return r
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPrimesUpTo(t *testing.T) {
+	tests := []struct {
+		input    int
+		expected []int
+	}{
+		{-5, nil},
+		{1, nil},
+		{2, []int{2}},
+		{10, []int{2, 3, 5, 7}},
+		{30, []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}},
+	}
+
+	for _, test := range tests {
+		if result := PrimesUpTo(test.input); fmt.Sprint(result) != fmt.Sprint(test.expected) {
+			t.Errorf("PrimesUpTo(%d) = %v; want %v", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestPrimesInRange(t *testing.T) {
+	tests := []struct {
+		lo, hi   int
+		expected []int
+	}{
+		{0, 1, nil},
+		{20, 10, nil},
+		{14, 16, nil},
+		{10, 30, []int{11, 13, 17, 19, 23, 29}},
+		{100, 130, []int{101, 103, 107, 109, 113, 127}},
+		{1000000, 1000100, []int{1000003, 1000033, 1000037, 1000039, 1000081, 1000099}},
+	}
+
+	for _, test := range tests {
+		if result := PrimesInRange(test.lo, test.hi); fmt.Sprint(result) != fmt.Sprint(test.expected) {
+			t.Errorf("PrimesInRange(%d, %d) = %v; want %v", test.lo, test.hi, result, test.expected)
+		}
+	}
+}
+
+func TestPrimesUpToCount(t *testing.T) {
+	// pi(n) for powers of ten; 10^6 spans many sieve segments.
+	tests := []struct {
+		input    int
+		expected int
+	}{
+		{100, 25},
+		{1000, 168},
+		{10000, 1229},
+		{1000000, 78498},
+	}
+
+	for _, test := range tests {
+		if result := len(PrimesUpTo(test.input)); result != test.expected {
+			t.Errorf("len(PrimesUpTo(%d)) = %d; want %d", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestPrimeTable(t *testing.T) {
+	table := NewPrimeTable(0, 100000)
+
+	for n := 0; n <= 100000; n++ {
+		if result, expected := table.IsPrime(n), IsPrime(n); result != expected {
+			t.Fatalf("PrimeTable.IsPrime(%d) = %v; want %v", n, result, expected)
+		}
+	}
+
+	tests := []struct {
+		input    int
+		expected bool
+	}{
+		{-7, false},
+		{0, false},
+		{1, false},
+		{2, true},
+		{99991, true},
+		{100003, true},
+		{100005, false},
+	}
+
+	for _, test := range tests {
+		if result := table.IsPrime(test.input); result != test.expected {
+			t.Errorf("PrimeTable.IsPrime(%d) = %v; want %v", test.input, result, test.expected)
+		}
+	}
+}
+
+const benchmarkLimit = 1000000
+
+func BenchmarkClassifyTrialDivision(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n <= benchmarkLimit; n++ {
+			IsPrime(n)
+		}
+	}
+}
+
+func BenchmarkClassifyPrimeTable(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		table := NewPrimeTable(0, benchmarkLimit)
+		for n := 0; n <= benchmarkLimit; n++ {
+			table.IsPrime(n)
+		}
+	}
+}
+
+func BenchmarkPrimeTableLookup(b *testing.B) {
+	table := NewPrimeTable(0, benchmarkLimit)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		table.IsPrime(i % benchmarkLimit)
+	}
+}
+
+func BenchmarkIsPrime(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		IsPrime(i % benchmarkLimit)
+	}
+}
+
+func BenchmarkPrimesUpTo(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		PrimesUpTo(benchmarkLimit)
+	}
+}
@@ -1,29 +1,33 @@
+//go:build ignore
+
+// Cubes numbers on a fixed pool of workers, like parallel_cal_squares.go.
+// pool.Map returns the results in input order, so results[i] is the cube of numbers[i].
+//
+// Usage: go run execise_cal_cube.go
+
 package main
 
 import (
+	"context"
+	"day10/pool"
 	"fmt"
-	"sync"
+	"runtime"
 )
 
-func cube(num int, ch chan int, wg *sync.WaitGroup) {
-	defer wg.Done()
-	ch <- num * num * num
+func cube(ctx context.Context, num int) (int, error) {
+	return num * num * num, nil
 }
 
 func main() {
 	numbers := []int{2, 4, 6, 8, 10}
-	ch := make(chan int, len(numbers))
-	var wg sync.WaitGroup
 
-	for _, num := range numbers {
-		wg.Add(1)
-		go cube(num, ch, &wg)
+	results, err := pool.Map(context.Background(), numbers, runtime.NumCPU(), cube)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
 	}
 
-	wg.Wait()
-	close(ch)
-
-	for result := range ch {
-		fmt.Println(result)
+	for i, result := range results {
+		fmt.Printf("%d^3 = %d\n", numbers[i], result)
 	}
 }
@@ -0,0 +1,3 @@
+module day10
+
+go 1.23.5
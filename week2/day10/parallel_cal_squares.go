@@ -1,29 +1,43 @@
+//go:build ignore
+
+// Squares numbers on a fixed pool of workers.
+// Unlike one goroutine per number, the worker count stays the same however
+// many numbers there are. pool.MapUnordered tags every result with the index
+// of its input, and pool.Reorder streams them back in input order as soon as
+// the earlier ones are done.
+//
+// Usage: go run parallel_cal_squares.go
+
 package main
 
 import (
+	"context"
+	"day10/pool"
 	"fmt"
-	"sync"
+	"runtime"
 )
 
-func square(num int, ch chan int, wg *sync.WaitGroup) {
-	defer wg.Done()
-	ch <- num * num
+func square(ctx context.Context, num int) (int, error) {
+	return num * num, nil
 }
 
 func main() {
 	numbers := []int{2, 4, 6, 8, 10}
-	ch := make(chan int, len(numbers)) // Buffered channel
-	var wg sync.WaitGroup
+	ctx := context.Background()
 
-	for _, num := range numbers {
-		wg.Add(1)
-		go square(num, ch, &wg)
-	}
+	inputs := make(chan int)
+	go func() {
+		for _, num := range numbers {
+			inputs <- num
+		}
+		close(inputs)
+	}()
 
-	wg.Wait()
-	close(ch)
-
-	for result := range ch {
-		fmt.Println(result)
+	results, wait := pool.MapUnordered(ctx, inputs, runtime.NumCPU(), square)
+	for result := range pool.Reorder(ctx, results) {
+		fmt.Printf("%d^2 = %d\n", numbers[result.Index], result.Value)
+	}
+	if err := wait(); err != nil {
+		fmt.Println("Error:", err)
 	}
 }
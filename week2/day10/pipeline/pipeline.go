@@ -0,0 +1,224 @@
+// Package pipeline builds fan-out/fan-in channel pipelines out of small stages.
+//
+// Every stage runs in its own goroutines, reads from the channel returned by
+// the previous one and closes its own output when its input is exhausted.
+// All of them select on ctx for every send and receive, so cancelling ctx
+// anywhere in the middle of a pipeline makes every goroutine return, even if
+// nobody reads the remaining values.
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Source sends values one by one on the returned channel, which is closed
+// after the last value or when ctx is cancelled.
+func Source[T any](ctx context.Context, values ...T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for _, v := range values {
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Stage applies fn to every value from in on parallelism goroutines and sends
+// the results on the returned channel, which is closed once in is closed and
+// every result has been sent, or when ctx is cancelled.
+//
+// With parallelism above 1 results are sent in completion order; use
+// pool.MapStream from the day10 module when input order matters.
+func Stage[T, R any](ctx context.Context, in <-chan T, parallelism int, fn func(context.Context, T) R) <-chan R {
+	out := make(chan R)
+	var wg sync.WaitGroup
+	for i := 0; i < max(parallelism, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case v, ok := <-in:
+					if !ok {
+						return
+					}
+					r := fn(ctx, v)
+					select {
+					case out <- r:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Merge sends every value from all ins on a single channel (fan-in), which
+// is closed once all ins are closed or when ctx is cancelled.
+func Merge[T any](ctx context.Context, ins ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	for _, in := range ins {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case v, ok := <-in:
+					if !ok {
+						return
+					}
+					select {
+					case out <- v:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Tee sends every value from in on both returned channels. A value is only
+// read from in after both have received the previous one, so the slower
+// reader sets the pace; both must be read until they are closed, or ctx
+// cancelled.
+func Tee[T any](ctx context.Context, in <-chan T) (<-chan T, <-chan T) {
+	out1 := make(chan T)
+	out2 := make(chan T)
+	go func() {
+		defer close(out1)
+		defer close(out2)
+		for {
+			var v T
+			select {
+			case value, ok := <-in:
+				if !ok {
+					return
+				}
+				v = value
+			case <-ctx.Done():
+				return
+			}
+
+			// Send to whichever reader is ready first, then to the other one.
+			o1, o2 := out1, out2
+			for o1 != nil || o2 != nil {
+				select {
+				case o1 <- v:
+					o1 = nil
+				case o2 <- v:
+					o2 = nil
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out1, out2
+}
+
+// Batch groups values from in into slices of up to size values. A batch is
+// sent when it is full, when maxWait has passed since its first value
+// (0 waits for a full batch), or when in is closed. The returned channel is
+// closed after the last batch or when ctx is cancelled; a partial batch is
+// dropped on cancellation.
+func Batch[T any](ctx context.Context, in <-chan T, size int, maxWait time.Duration) <-chan []T {
+	size = max(size, 1)
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+
+		var batch []T
+		var timer *time.Timer
+		var timeout <-chan time.Time
+		flush := func() bool {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+			if len(batch) == 0 {
+				return true
+			}
+			select {
+			case out <- batch:
+				batch = nil
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				batch = append(batch, v)
+				if len(batch) == 1 && maxWait > 0 {
+					timer = time.NewTimer(maxWait)
+					timeout = timer.C
+				}
+				if len(batch) >= size && !flush() {
+					return
+				}
+			case <-timeout:
+				if !flush() {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Sink calls fn for every value from in until in is closed. It stops at the
+// first error of fn and returns it, or returns ctx's error if ctx is
+// cancelled first, including when in was closed because of the cancellation.
+// Cancel ctx after a failed Sink so the stages before it stop too.
+func Sink[T any](ctx context.Context, in <-chan T, fn func(T) error) error {
+	for {
+		select {
+		case v, ok := <-in:
+			if !ok {
+				return ctx.Err()
+			}
+			if err := fn(v); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+// checkNoLeaks fails the test if goroutines started during it are still running.
+func checkNoLeaks(t *testing.T) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		deadline := time.Now().Add(2 * time.Second)
+		for runtime.NumGoroutine() > before {
+			if time.Now().After(deadline) {
+				buf := make([]byte, 1<<16)
+				t.Errorf("%d goroutines leaked:\n%s", runtime.NumGoroutine()-before, buf[:runtime.Stack(buf, true)])
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+}
+
+func collect[T any](in <-chan T) []T {
+	var values []T
+	for v := range in {
+		values = append(values, v)
+	}
+	return values
+}
+
+func numbers(n int) []int {
+	values := make([]int, n)
+	for i := range values {
+		values[i] = i
+	}
+	return values
+}
+
+func square(ctx context.Context, n int) int {
+	return n * n
+}
+
+func TestSourceStageSink(t *testing.T) {
+	tests := []struct {
+		parallelism int
+		n           int
+	}{
+		{1, 0},
+		{1, 10},
+		{4, 100},
+		{0, 5}, // at least one goroutine is used
+	}
+
+	checkNoLeaks(t)
+	for _, test := range tests {
+		ctx := context.Background()
+		var got []int
+		err := Sink(ctx, Stage(ctx, Source(ctx, numbers(test.n)...), test.parallelism, square), func(v int) error {
+			got = append(got, v)
+			return nil
+		})
+		if err != nil {
+			t.Errorf("Sink error %v", err)
+		}
+
+		want := make([]int, test.n)
+		for i := range want {
+			want[i] = i * i
+		}
+		if test.parallelism > 1 {
+			slices.Sort(got) // completion order
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("Stage(parallelism %d) of %d values = %v; want %v", test.parallelism, test.n, got, want)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	checkNoLeaks(t)
+	ctx := context.Background()
+	got := collect(Merge(ctx, Source(ctx, 1, 2, 3), Source[int](ctx), Source(ctx, 4, 5)))
+	slices.Sort(got)
+	if fmt.Sprint(got) != "[1 2 3 4 5]" {
+		t.Errorf("Merge = %v; want [1 2 3 4 5]", got)
+	}
+	if got := collect(Merge[int](ctx)); got != nil {
+		t.Errorf("Merge() = %v; want nothing", got)
+	}
+}
+
+func TestTee(t *testing.T) {
+	checkNoLeaks(t)
+	ctx := context.Background()
+	a, b := Tee(ctx, Source(ctx, numbers(50)...))
+
+	done := make(chan []int)
+	go func() { done <- collect(b) }()
+	gotA := collect(a)
+	gotB := <-done
+
+	want := fmt.Sprint(numbers(50))
+	if fmt.Sprint(gotA) != want || fmt.Sprint(gotB) != want {
+		t.Errorf("Tee = %v, %v; want both %v", gotA, gotB, want)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	tests := []struct {
+		n        int
+		size     int
+		expected string
+	}{
+		{0, 3, "[]"},
+		{6, 3, "[[0 1 2] [3 4 5]]"},
+		{7, 3, "[[0 1 2] [3 4 5] [6]]"},
+		{3, 0, "[[0] [1] [2]]"}, // size below 1 means 1
+	}
+
+	checkNoLeaks(t)
+	for _, test := range tests {
+		ctx := context.Background()
+		got := collect(Batch(ctx, Source(ctx, numbers(test.n)...), test.size, 0))
+		if fmt.Sprint(got) != test.expected {
+			t.Errorf("Batch(%d values, %d) = %v; want %s", test.n, test.size, got, test.expected)
+		}
+	}
+}
+
+func TestBatchMaxWait(t *testing.T) {
+	checkNoLeaks(t)
+	ctx := context.Background()
+	in := make(chan int)
+	batches := Batch(ctx, in, 10, 20*time.Millisecond)
+
+	in <- 1
+	in <- 2
+	select {
+	case got := <-batches:
+		if fmt.Sprint(got) != "[1 2]" {
+			t.Errorf("partial batch = %v; want [1 2]", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("partial batch was not sent after maxWait")
+	}
+
+	in <- 3
+	close(in)
+	if got := <-batches; fmt.Sprint(got) != "[3]" {
+		t.Errorf("last batch = %v; want [3]", got)
+	}
+	if _, ok := <-batches; ok {
+		t.Error("Batch output was not closed")
+	}
+}
+
+func TestSinkError(t *testing.T) {
+	checkNoLeaks(t)
+	errStop := errors.New("stop")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	seen := 0
+	err := Sink(ctx, Source(ctx, numbers(100)...), func(v int) error {
+		seen++
+		if v == 3 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) || seen != 4 {
+		t.Errorf("Sink = %v after %d values; want %v after 4", err, seen, errStop)
+	}
+}
+
+func TestSinkClosedByCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	closed := make(chan int)
+	close(closed)
+
+	// Both cases of Sink's select are ready; either must report the cancellation.
+	for i := 0; i < 100; i++ {
+		if err := Sink(ctx, closed, func(int) error { return nil }); !errors.Is(err, context.Canceled) {
+			t.Fatalf("Sink of a closed channel after cancel = %v; want context.Canceled", err)
+		}
+	}
+}
+
+// TestCancelMidPipeline cancels a pipeline using every stage while values are
+// still flowing and nobody drains the channels, then checks that every
+// goroutine has returned.
+func TestCancelMidPipeline(t *testing.T) {
+	checkNoLeaks(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	source := Source(ctx, numbers(100000)...)
+	squares := Stage(ctx, source, 4, square)
+	a, b := Tee(ctx, squares)
+	doubled := Stage(ctx, a, 2, func(ctx context.Context, n int) int { return 2 * n })
+	merged := Merge(ctx, doubled, b)
+	batches := Batch(ctx, merged, 8, time.Millisecond)
+
+	received := 0
+	err := Sink(ctx, batches, func(batch []int) error {
+		received += len(batch)
+		if received >= 100 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Sink = %v; want context.Canceled", err)
+	}
+	if received >= 2*100000 {
+		t.Errorf("received all %d values; want the pipeline stopped early", received)
+	}
+}
+
+func ExampleStage() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	squares := Stage(ctx, Source(ctx, 1, 2, 3, 4, 5, 6), 1, square)
+	Sink(ctx, Batch(ctx, squares, 4, 0), func(batch []int) error {
+		fmt.Println(batch)
+		return nil
+	})
+	// Output:
+	// [1 4 9 16]
+	// [25 36]
+}
+
+func BenchmarkStage(b *testing.B) {
+	ctx := context.Background()
+	values := numbers(1000)
+	for i := 0; i < b.N; i++ {
+		Sink(ctx, Stage(ctx, Source(ctx, values...), 4, square), func(int) error { return nil })
+	}
+}
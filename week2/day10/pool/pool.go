@@ -0,0 +1,319 @@
+// Package pool runs a function over many inputs on a fixed number of worker
+// goroutines, instead of starting one goroutine per input.
+//
+// Results come back in input order, the first error cancels the remaining
+// work, and at most a few inputs per worker are in flight at once, so
+// MapStream works on input streams of any length in constant memory.
+package pool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Map applies fn to every element of inputs on workers goroutines and returns
+// the results in input order.
+//
+// If fn fails, the context passed to the other calls is cancelled, fn is not
+// called again once it is, and Map returns the first error in input order,
+// annotated with the index of its input. If ctx is cancelled first, Map
+// returns ctx's error.
+func Map[T, R any](ctx context.Context, inputs []T, workers int, fn func(context.Context, T) (R, error)) ([]R, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	in := make(chan T)
+	go func() {
+		defer close(in)
+		for _, v := range inputs {
+			select {
+			case in <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	out, wait := MapStream(ctx, in, workers, fn)
+	results := make([]R, 0, len(inputs))
+	for r := range out {
+		results = append(results, r)
+	}
+	if err := wait(); err != nil {
+		return nil, err
+	}
+	if len(results) < len(inputs) {
+		// ctx was cancelled before every input was handed over.
+		return nil, ctx.Err()
+	}
+	return results, nil
+}
+
+// MapStream applies fn to every value received from inputs on workers
+// goroutines and sends the results, in input order, on the returned channel.
+// It reads a new input only when there is room for it, so a slow consumer
+// slows down the producer instead of piling up results.
+//
+// The channel is closed once inputs is closed and every result has been
+// sent, or as soon as fn fails or ctx is cancelled. The returned wait
+// function then reports why: nil, the first error of fn annotated with the
+// index of its input, or ctx's error.
+//
+// A consumer that stops reading early must cancel ctx, otherwise the
+// goroutines of MapStream block forever.
+func MapStream[T, R any](ctx context.Context, inputs <-chan T, workers int, fn func(context.Context, T) (R, error)) (<-chan R, func() error) {
+	if workers < 1 {
+		workers = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+
+	type result struct {
+		value R
+		err   error
+	}
+	type job struct {
+		input T
+		out   chan result
+	}
+
+	jobs := make(chan job)
+	pending := make(chan chan result, workers)
+	results := make(chan R)
+	done := make(chan struct{})
+	var err, dispatchErr error
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				// The dispatcher may still hand out a job after cancellation.
+				if err := ctx.Err(); err != nil {
+					j.out <- result{err: err}
+					continue
+				}
+				value, err := fn(ctx, j.input)
+				j.out <- result{value, err}
+			}
+		}()
+	}
+
+	// Dispatch inputs to the workers and queue each result slot in input order.
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+		for {
+			var input T
+			select {
+			case v, ok := <-inputs:
+				if !ok {
+					return
+				}
+				input = v
+			case <-ctx.Done():
+				dispatchErr = ctx.Err()
+				return
+			}
+
+			out := make(chan result, 1)
+			select {
+			case pending <- out:
+			case <-ctx.Done():
+				dispatchErr = ctx.Err()
+				return
+			}
+			select {
+			case jobs <- job{input: input, out: out}:
+			case <-ctx.Done():
+				dispatchErr = ctx.Err()
+				return
+			}
+		}
+	}()
+
+	// Collect results in the order their slots were queued.
+	go func() {
+		defer close(done)
+		defer close(results)
+		index := 0
+	collect:
+		for out := range pending {
+			var r result
+			select {
+			case r = <-out:
+			case <-ctx.Done():
+				err = ctx.Err()
+				break collect
+			}
+			if r.err != nil {
+				err = fmt.Errorf("input %d: %w", index, r.err)
+				break collect
+			}
+			select {
+			case results <- r.value:
+			case <-ctx.Done():
+				err = ctx.Err()
+				break collect
+			}
+			index++
+		}
+		if err == nil {
+			// pending is closed, so the dispatcher is done with dispatchErr.
+			err = dispatchErr
+		}
+		cancel()
+		for range pending {
+		}
+		wg.Wait()
+	}()
+
+	return results, func() error {
+		<-done
+		return err
+	}
+}
+
+// Indexed is a value tagged with the index of the input it was computed from.
+type Indexed[T any] struct {
+	Index int
+	Value T
+}
+
+// MapUnordered is like MapStream, but sends each result as soon as it is
+// ready, tagged with the index of its input, instead of waiting for the
+// results of earlier inputs. Pass the channel to Reorder or Collect to get
+// input order back.
+func MapUnordered[T, R any](ctx context.Context, inputs <-chan T, workers int, fn func(context.Context, T) (R, error)) (<-chan Indexed[R], func() error) {
+	if workers < 1 {
+		workers = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+
+	jobs := make(chan Indexed[T])
+	results := make(chan Indexed[R])
+	done := make(chan struct{})
+	var once sync.Once
+	var err error
+	fail := func(e error) {
+		once.Do(func() { err = e })
+		cancel()
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				if err := ctx.Err(); err != nil {
+					fail(err)
+					return
+				}
+				value, err := fn(ctx, j.Value)
+				if err != nil {
+					fail(fmt.Errorf("input %d: %w", j.Index, err))
+					return
+				}
+				select {
+				case results <- Indexed[R]{Index: j.Index, Value: value}:
+				case <-ctx.Done():
+					fail(ctx.Err())
+					return
+				}
+			}
+		}()
+	}
+
+	// Tag inputs with their index and hand them to the workers.
+	go func() {
+		defer close(jobs)
+		for index := 0; ; index++ {
+			var input T
+			select {
+			case v, ok := <-inputs:
+				if !ok {
+					return
+				}
+				input = v
+			case <-ctx.Done():
+				fail(ctx.Err())
+				return
+			}
+			select {
+			case jobs <- Indexed[T]{Index: index, Value: input}:
+			case <-ctx.Done():
+				fail(ctx.Err())
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		cancel()
+		close(results)
+		close(done)
+	}()
+
+	return results, func() error {
+		<-done
+		return err
+	}
+}
+
+// Reorder reads values tagged with indices 0, 1, 2, ... in any order and
+// sends them in index order, holding back values that arrive early.
+// The buffer only grows as far as values run ahead of the next missing index.
+//
+// The returned channel is closed when in is closed or ctx is cancelled.
+// Buffered values behind an index that never arrived are dropped, so the
+// output always covers the indices 0 to n-1 without gaps.
+func Reorder[T any](ctx context.Context, in <-chan Indexed[T]) <-chan Indexed[T] {
+	out := make(chan Indexed[T])
+	go func() {
+		defer close(out)
+		early := make(map[int]T)
+		next := 0
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				early[v.Index] = v.Value
+			case <-ctx.Done():
+				return
+			}
+
+			for {
+				value, ok := early[next]
+				if !ok {
+					break
+				}
+				delete(early, next)
+				select {
+				case out <- Indexed[T]{Index: next, Value: value}:
+				case <-ctx.Done():
+					return
+				}
+				next++
+			}
+		}
+	}()
+	return out
+}
+
+// Collect reads every value from in and returns the values in index order.
+// Indices that never arrived are left as the zero value.
+func Collect[T any](in <-chan Indexed[T]) []T {
+	var values []T
+	for v := range in {
+		if v.Index >= len(values) {
+			values = append(values, make([]T, v.Index+1-len(values))...)
+		}
+		values[v.Index] = v.Value
+	}
+	return values
+}
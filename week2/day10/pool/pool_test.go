@@ -0,0 +1,371 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// checkNoLeaks fails the test if goroutines started during it are still running.
+func checkNoLeaks(t *testing.T) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		deadline := time.Now().Add(2 * time.Second)
+		for runtime.NumGoroutine() > before {
+			if time.Now().After(deadline) {
+				buf := make([]byte, 1<<16)
+				t.Errorf("%d goroutines leaked:\n%s", runtime.NumGoroutine()-before, buf[:runtime.Stack(buf, true)])
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+}
+
+func square(ctx context.Context, n int) (int, error) {
+	// Later inputs finish first, so ordering is really exercised.
+	time.Sleep(time.Duration(10-n%10) * time.Millisecond)
+	return n * n, nil
+}
+
+func TestMapPreservesOrder(t *testing.T) {
+	tests := []struct {
+		inputs  []int
+		workers int
+	}{
+		{nil, 3},
+		{[]int{2, 4, 6, 8, 10}, 1},
+		{[]int{2, 4, 6, 8, 10}, 3},
+		{[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}, 4},
+		{[]int{5, 3}, 0}, // at least one worker is used
+	}
+
+	checkNoLeaks(t)
+	for _, test := range tests {
+		results, err := Map(context.Background(), test.inputs, test.workers, square)
+		if err != nil {
+			t.Errorf("Map(%v, %d) error %v", test.inputs, test.workers, err)
+			continue
+		}
+		if len(results) != len(test.inputs) {
+			t.Errorf("Map(%v, %d) = %v; want %d results", test.inputs, test.workers, results, len(test.inputs))
+			continue
+		}
+		for i, n := range test.inputs {
+			if results[i] != n*n {
+				t.Errorf("Map(%v, %d) = %v; result %d is %d, want %d", test.inputs, test.workers, results, i, results[i], n*n)
+				break
+			}
+		}
+	}
+}
+
+func TestMapErrorCancelsRest(t *testing.T) {
+	checkNoLeaks(t)
+	errBoom := errors.New("boom")
+	inputs := make([]int, 1000)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	var calls atomic.Int32
+	results, err := Map(context.Background(), inputs, 4, func(ctx context.Context, n int) (int, error) {
+		calls.Add(1)
+		if n == 10 {
+			return 0, errBoom
+		}
+		select {
+		case <-time.After(time.Millisecond):
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+		return n, nil
+	})
+
+	if !errors.Is(err, errBoom) || results != nil {
+		t.Fatalf("Map = %v, %v; want nil, %v", results, err, errBoom)
+	}
+	if want := "input 10: boom"; err.Error() != want {
+		t.Errorf("error = %q; want %q", err, want)
+	}
+	if n := calls.Load(); n > 100 {
+		t.Errorf("fn was called %d times after the error; want the rest cancelled", n)
+	}
+}
+
+func TestMapContextCancelled(t *testing.T) {
+	checkNoLeaks(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Map(ctx, []int{1, 2, 3}, 2, square)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Map with cancelled context error %v; want context.Canceled", err)
+	}
+}
+
+func TestMapStreamUnbounded(t *testing.T) {
+	checkNoLeaks(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// An endless input stream: only backpressure keeps this from running away.
+	var produced atomic.Int64
+	inputs := make(chan int)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case inputs <- i:
+				produced.Add(1)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	const workers = 4
+	var running, maxRunning atomic.Int32
+	results, wait := MapStream(ctx, inputs, workers, func(ctx context.Context, n int) (int, error) {
+		r := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if r <= m || maxRunning.CompareAndSwap(m, r) {
+				break
+			}
+		}
+		return 2 * n, nil
+	})
+
+	for i := 0; i < 100; i++ {
+		if r := <-results; r != 2*i {
+			t.Fatalf("result %d = %d; want %d", i, r, 2*i)
+		}
+	}
+	time.Sleep(20 * time.Millisecond) // let the pool fill up while nobody reads
+	if n := produced.Load(); n > 100+3*workers {
+		t.Errorf("%d inputs were read for 100 consumed results; want backpressure", n)
+	}
+	if m := maxRunning.Load(); m > workers {
+		t.Errorf("%d calls ran at once; want at most %d", m, workers)
+	}
+
+	cancel()
+	for range results {
+	}
+	if err := wait(); !errors.Is(err, context.Canceled) {
+		t.Errorf("wait() = %v; want context.Canceled", err)
+	}
+}
+
+func ExampleMap() {
+	numbers := []int{2, 4, 6, 8, 10}
+	squares, err := Map(context.Background(), numbers, 3, func(ctx context.Context, n int) (int, error) {
+		return n * n, nil
+	})
+	fmt.Println(squares, err)
+	// Output: [4 16 36 64 100] <nil>
+}
+
+func BenchmarkMap(b *testing.B) {
+	inputs := make([]int, 1000)
+	for i := range inputs {
+		inputs[i] = i
+	}
+	double := func(ctx context.Context, n int) (int, error) { return 2 * n, nil }
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Map(context.Background(), inputs, 4, double)
+	}
+}
+
+func TestReorder(t *testing.T) {
+	tests := []struct {
+		indices  []int
+		expected []int
+	}{
+		{nil, nil},
+		{[]int{0, 1, 2}, []int{0, 1, 2}},
+		{[]int{3, 1, 0, 2, 4}, []int{0, 1, 2, 3, 4}},
+		{[]int{2, 0, 4}, []int{0}}, // 1 never arrives, so 2 and 4 are dropped
+	}
+
+	checkNoLeaks(t)
+	for _, test := range tests {
+		in := make(chan Indexed[string])
+		go func() {
+			for _, i := range test.indices {
+				in <- Indexed[string]{Index: i, Value: fmt.Sprint("v", i)}
+			}
+			close(in)
+		}()
+
+		var got []int
+		for v := range Reorder(context.Background(), in) {
+			if v.Value != fmt.Sprint("v", v.Index) {
+				t.Errorf("Reorder(%v) sent %+v; value does not match index", test.indices, v)
+			}
+			got = append(got, v.Index)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(test.expected) {
+			t.Errorf("Reorder(%v) = %v; want %v", test.indices, got, test.expected)
+		}
+	}
+}
+
+func TestCollect(t *testing.T) {
+	in := make(chan Indexed[int], 3)
+	in <- Indexed[int]{Index: 2, Value: 30}
+	in <- Indexed[int]{Index: 0, Value: 10}
+	in <- Indexed[int]{Index: 1, Value: 20}
+	close(in)
+
+	if got := Collect(in); fmt.Sprint(got) != "[10 20 30]" {
+		t.Errorf("Collect = %v; want [10 20 30]", got)
+	}
+}
+
+// TestSquaresAndCubesInOrder computes squares and cubes with random delays,
+// so results complete out of order, and checks every result is matched back
+// to its input. Run it with -race.
+func TestSquaresAndCubesInOrder(t *testing.T) {
+	checkNoLeaks(t)
+	numbers := make([]int, 200)
+	for i := range numbers {
+		numbers[i] = i + 1
+	}
+	delayed := func(f func(int) int) func(context.Context, int) (int, error) {
+		return func(ctx context.Context, n int) (int, error) {
+			time.Sleep(time.Duration(rand.IntN(500)) * time.Microsecond)
+			return f(n), nil
+		}
+	}
+	square := delayed(func(n int) int { return n * n })
+	cube := delayed(func(n int) int { return n * n * n })
+
+	tests := []struct {
+		name string
+		fn   func(context.Context, int) (int, error)
+		want func(int) int
+	}{
+		{"square", square, func(n int) int { return n * n }},
+		{"cube", cube, func(n int) int { return n * n * n }},
+	}
+
+	for _, test := range tests {
+		inputs := make(chan int)
+		go func() {
+			for _, n := range numbers {
+				inputs <- n
+			}
+			close(inputs)
+		}()
+
+		results, wait := MapUnordered(context.Background(), inputs, 8, test.fn)
+		next := 0
+		for v := range Reorder(context.Background(), results) {
+			if v.Index != next {
+				t.Fatalf("%s: Reorder sent index %d; want %d", test.name, v.Index, next)
+			}
+			if want := test.want(numbers[v.Index]); v.Value != want {
+				t.Errorf("%s(%d) = %d; want %d", test.name, numbers[v.Index], v.Value, want)
+			}
+			next++
+		}
+		if err := wait(); err != nil {
+			t.Errorf("%s: wait() = %v", test.name, err)
+		}
+		if next != len(numbers) {
+			t.Errorf("%s: got %d results; want %d", test.name, next, len(numbers))
+		}
+
+		// Collect puts the same results back in input order without streaming.
+		inputs = make(chan int)
+		go func() {
+			for _, n := range numbers {
+				inputs <- n
+			}
+			close(inputs)
+		}()
+		results, wait = MapUnordered(context.Background(), inputs, 8, test.fn)
+		unordered := Collect(results)
+		for i, v := range unordered {
+			if v != test.want(numbers[i]) {
+				t.Errorf("%s: Collect result %d = %d; want %d", test.name, i, v, test.want(numbers[i]))
+				break
+			}
+		}
+		if err := wait(); err != nil {
+			t.Errorf("%s: wait() = %v", test.name, err)
+		}
+	}
+}
+
+func TestMapUnorderedError(t *testing.T) {
+	checkNoLeaks(t)
+	errBoom := errors.New("boom")
+	inputs := make(chan int)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		defer close(inputs)
+		for i := 0; ; i++ {
+			select {
+			case inputs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results, wait := MapUnordered(ctx, inputs, 4, func(ctx context.Context, n int) (int, error) {
+		if n == 7 {
+			return 0, errBoom
+		}
+		return n, nil
+	})
+	for range results {
+	}
+	if err := wait(); !errors.Is(err, errBoom) || err.Error() != "input 7: boom" {
+		t.Errorf("wait() = %v; want input 7: boom", err)
+	}
+}
+
+func TestMapUnorderedNoCallsAfterError(t *testing.T) {
+	checkNoLeaks(t)
+	errBoom := errors.New("boom")
+	inputs := make(chan int)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		defer close(inputs)
+		for i := 0; ; i++ {
+			select {
+			case inputs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// A single worker fails on its first input; it must not run another one.
+	var calls atomic.Int32
+	results, wait := MapUnordered(ctx, inputs, 1, func(ctx context.Context, n int) (int, error) {
+		calls.Add(1)
+		return 0, errBoom
+	})
+	for range results {
+	}
+	if err := wait(); !errors.Is(err, errBoom) {
+		t.Errorf("wait() = %v; want %v", err, errBoom)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("fn was called %d times; want 1", n)
+	}
+}
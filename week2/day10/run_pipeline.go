@@ -0,0 +1,42 @@
+//go:build ignore
+
+// Generalizes send_data_via_channel.go from a single hop to a pipeline:
+// a Source of numbers fans out to a Stage of three workers that square them,
+// a Tee copies the numbers to a second Stage that cubes them, Merge fans both
+// back in, Batch groups the results and a Sink prints them.
+// Ctrl+C cancels the context, which stops every goroutine of the pipeline.
+//
+// Usage: go run run_pipeline.go
+
+package main
+
+import (
+	"context"
+	"day10/pipeline"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	forSquares, forCubes := pipeline.Tee(ctx, pipeline.Source(ctx, 2, 4, 6, 8, 10))
+	squares := pipeline.Stage(ctx, forSquares, 3, func(ctx context.Context, n int) string {
+		return fmt.Sprintf("%d^2 = %d", n, n*n)
+	})
+	cubes := pipeline.Stage(ctx, forCubes, 1, func(ctx context.Context, n int) string {
+		return fmt.Sprintf("%d^3 = %d", n, n*n*n)
+	})
+
+	batches := pipeline.Batch(ctx, pipeline.Merge(ctx, squares, cubes), 4, 100*time.Millisecond)
+	err := pipeline.Sink(ctx, batches, func(batch []string) error {
+		fmt.Println(batch)
+		return nil
+	})
+	if err != nil {
+		fmt.Println("Error:", err)
+	}
+}
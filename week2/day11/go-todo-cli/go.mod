@@ -1,3 +1,16 @@
 module go-todo-cli
 
 go 1.23.5
+
+require mymodule v0.0.0-00010101000000-000000000000
+
+require (
+	github.com/fatih/color v1.18.0 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	golang.org/x/sys v0.25.0 // indirect
+)
+
+replace mymodule => ../../day9/mymodule
+
+replace execise => ../../day9/execise
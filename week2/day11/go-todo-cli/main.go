@@ -3,7 +3,9 @@ package main
 import (
 	"bufio"
 	"encoding/json"
+	"flag"
 	"fmt"
+	"mymodule/output"
 	"os"
 	"strconv"
 )
@@ -17,6 +19,9 @@ type Task struct {
 // Filename for storing tasks
 const taskFile = "tasks.json"
 
+// out prints everything the CLI shows; main sets its color mode from --color.
+var out = output.New(os.Stdout, output.Auto)
+
 func LoadTasks() ([]Task, error) {
 	file, err := os.Open(taskFile)
 	if err != nil {
@@ -62,21 +67,21 @@ func AddTask(description string) error {
 func ListTasks() {
 	tasks, err := LoadTasks()
 	if err != nil {
-		fmt.Println("Error loading tasks:", err)
+		out.Error("Error loading tasks:", err)
 		return
 	}
 
 	if len(tasks) == 0 {
-		fmt.Println("No tasks found.")
+		out.Muted("No tasks found.")
 		return
 	}
 
 	for i, task := range tasks {
-		status := " "
 		if task.Completed {
-			status = "v"
+			out.Printf(output.Muted, "%d. [v] %s\n", i+1, task.Description)
+		} else {
+			fmt.Printf("%d. [ ] %s\n", i+1, task.Description)
 		}
-		fmt.Printf("%d. [%s] %s\n", i+1, status, task.Description)
 	}
 }
 
@@ -112,6 +117,11 @@ func RemoveTask(index int) error {
 
 // CLI Menu
 func main() {
+	var colorMode output.Mode
+	flag.Var(&colorMode, "color", output.FlagUsage)
+	flag.Parse()
+	out = output.New(os.Stdout, colorMode)
+
 	scanner := bufio.NewScanner(os.Stdin)
 
 	for {
@@ -133,9 +143,9 @@ func main() {
 			description := scanner.Text()
 			err := AddTask(description)
 			if err != nil {
-				fmt.Println("Error adding task:", err)
+				out.Error("Error adding task:", err)
 			} else {
-				fmt.Println("Task added successfully.")
+				out.Success("Task added successfully.")
 			}
 
 		case "2":
@@ -146,14 +156,14 @@ func main() {
 			scanner.Scan()
 			index, err := strconv.Atoi(scanner.Text())
 			if err != nil {
-				fmt.Println("Invalid input. Enter a valid task number.")
+				out.Warning("Invalid input. Enter a valid task number.")
 				continue
 			}
 			err = MarkTaskAsDone((index - 1))
 			if err != nil {
-				fmt.Println("Error marking task:", err)
+				out.Error("Error marking task:", err)
 			} else {
-				fmt.Println("Task marked as completed.")
+				out.Success("Task marked as completed.")
 			}
 
 		case "4":
@@ -161,21 +171,21 @@ func main() {
 			scanner.Scan()
 			index, err := strconv.Atoi(scanner.Text())
 			if err != nil {
-				fmt.Println("Invalid input. Enter a valid task number.")
+				out.Warning("Invalid input. Enter a valid task number.")
 				continue
 			}
 			err = RemoveTask(index - 1)
 			if err != nil {
-				fmt.Println("Error removing task:", err)
+				out.Error("Error removing task:", err)
 			} else {
-				fmt.Println("Task removed successfully!")
+				out.Success("Task removed successfully!")
 			}
 
 		case "5":
-			fmt.Println("Goodbye!")
+			out.Muted("Goodbye!")
 			return
 		default:
-			fmt.Println("Invalid choice. Please choose a valid option.")
+			out.Warning("Invalid choice. Please choose a valid option.")
 
 		}
 	}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
+	"mymodule/output"
 	"os"
 	"strconv"
 )
@@ -17,6 +19,9 @@ type Task struct {
 // Filename for storing tasks
 const taskFile = "tasks.json"
 
+// out prints everything the CLI shows; main sets its color mode from --color.
+var out = output.New(os.Stdout, output.Auto)
+
 func loadTasks() ([]Task, error) {
 	file, err := os.Open(taskFile)
 	if err != nil {
@@ -68,21 +73,21 @@ func addTask(title string) error {
 func listTasks() error {
 	tasks, err := loadTasks()
 	if err != nil {
-		fmt.Println("Error loading tasks:", err)
+		out.Error("Error loading tasks:", err)
 		return err
 	}
 
 	if len(tasks) == 0 {
-		fmt.Println("No tasks found.")
+		out.Muted("No tasks found.")
 		return nil
 	}
 
 	for _, task := range tasks {
-		status := " "
 		if task.Completed {
-			status = "v"
+			out.Printf(output.Muted, "%d. [v] %s\n", task.ID, task.Title)
+		} else {
+			fmt.Printf("%d. [ ] %s\n", task.ID, task.Title)
 		}
-		fmt.Printf("%d. [%s] %s\n", task.ID, status, task.Title)
 	}
 	return nil
 }
@@ -126,67 +131,73 @@ func removeTask(id int) error {
 // CLI Menu
 
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: todo [add|list|done|remove] [task]")
+	var colorMode output.Mode
+	flag.Var(&colorMode, "color", output.FlagUsage)
+	flag.Parse()
+	out = output.New(os.Stdout, colorMode)
+
+	args := flag.Args()
+	if len(args) < 1 {
+		out.Warning("Usage: todo [--color=auto|always|never] [add|list|done|remove] [task]")
 		return
 	}
 
-	command := os.Args[1]
+	command := args[0]
 
 	switch command {
 	case "add":
-		if len(os.Args) < 3 {
-			fmt.Println("Error: Please provide a task description.")
+		if len(args) < 2 {
+			out.Error("Error: Please provide a task description.")
 			return
 		}
-		err := addTask(os.Args[2])
+		err := addTask(args[1])
 		if err != nil {
-			fmt.Println("Error:", err)
+			out.Error("Error:", err)
 		} else {
-			fmt.Println("Task added successfully.")
+			out.Success("Task added successfully.")
 		}
 
 	case "list":
 		err := listTasks()
 		if err != nil {
-			fmt.Println("Error:", err)
+			out.Error("Error:", err)
 		}
 
 	case "done":
-		if len(os.Args) < 3 {
-			fmt.Println("Error: Please provide a task ID.")
+		if len(args) < 2 {
+			out.Error("Error: Please provide a task ID.")
 			return
 		}
-		id, err := strconv.Atoi(os.Args[2])
+		id, err := strconv.Atoi(args[1])
 		if err != nil {
-			fmt.Println("Error: Invalid task ID.")
+			out.Error("Error: Invalid task ID.")
 			return
 		}
 		err = completeTask(id)
 		if err != nil {
-			fmt.Println("Error:", err)
+			out.Error("Error:", err)
 		} else {
-			fmt.Println("Task marked as completed.")
+			out.Success("Task marked as completed.")
 		}
 
 	case "remove":
-		if len(os.Args) < 3 {
-			fmt.Println("Error: Please provide a task ID.")
+		if len(args) < 2 {
+			out.Error("Error: Please provide a task ID.")
 			return
 		}
-		id, err := strconv.Atoi(os.Args[2])
+		id, err := strconv.Atoi(args[1])
 		if err != nil {
-			fmt.Println("Error: Invalid task ID.")
+			out.Error("Error: Invalid task ID.")
 			return
 		}
 		err = removeTask(id)
 		if err != nil {
-			fmt.Println("Error:", err)
+			out.Error("Error:", err)
 		} else {
-			fmt.Println("Task removed successfully.")
+			out.Success("Task removed successfully.")
 		}
 
 	default:
-		fmt.Println("Unknown command. Available commands: add, list, done, remove.")
+		out.Warning("Unknown command. Available commands: add, list, done, remove.")
 	}
 }
@@ -0,0 +1,231 @@
+// Package calc evaluates integer infix expressions such as "(3 + 4) * 2 / 7"
+// with the usual precedence, parentheses, unary minus and variables.
+// Arithmetic goes through the checked operations of the mathutil package, so
+// overflow and division by zero are reported as errors.
+package calc
+
+import (
+	"day13/mathutil"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrSyntax            = errors.New("syntax error")
+	ErrUndefinedVariable = errors.New("undefined variable")
+)
+
+// Calculator evaluates expressions and remembers variables between calls.
+type Calculator struct {
+	Vars map[string]int
+}
+
+// New returns a Calculator without variables.
+func New() *Calculator {
+	return &Calculator{Vars: make(map[string]int)}
+}
+
+// Eval evaluates a single line, which is either an expression or an
+// assignment "name = expression". An assignment stores and returns the value.
+// Errors wrap ErrSyntax, ErrUndefinedVariable, mathutil.ErrDiviedByZero or
+// mathutil.ErrOverflow.
+func (c *Calculator) Eval(line string) (int, error) {
+	tokens, err := tokenize(line)
+	if err != nil {
+		return 0, err
+	}
+
+	p := &parser{tokens: tokens, vars: c.Vars}
+	target := ""
+	if len(tokens) >= 2 && tokens[0].kind == identToken && tokens[1].text == "=" {
+		target = tokens[0].text
+		p.pos = 2
+	}
+
+	value, err := p.expr()
+	if err != nil {
+		return 0, err
+	}
+	if t := p.peek(); t.kind != endToken {
+		return 0, p.errorf(t, "unexpected %q", t.text)
+	}
+
+	if target != "" {
+		c.Vars[target] = value
+	}
+	return value, nil
+}
+
+type tokenKind int
+
+const (
+	endToken tokenKind = iota
+	numberToken
+	identToken
+	opToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int // byte offset in the line, for error messages
+}
+
+func tokenize(line string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(line); {
+		c, start := line[i], i
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+			continue
+		case isDigit(c):
+			for i < len(line) && isDigit(line[i]) {
+				i++
+			}
+			tokens = append(tokens, token{numberToken, line[start:i], start})
+		case isLetter(c):
+			for i < len(line) && (isLetter(line[i]) || isDigit(line[i])) {
+				i++
+			}
+			tokens = append(tokens, token{identToken, line[start:i], start})
+		case strings.IndexByte("+-*/%()=", c) >= 0:
+			i++
+			tokens = append(tokens, token{opToken, line[start:i], start})
+		default:
+			return nil, fmt.Errorf("%w at column %d: unexpected character %q", ErrSyntax, start+1, c)
+		}
+	}
+	return append(tokens, token{endToken, "end of input", len(line)}), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// parser is a recursive descent evaluator for:
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/" | "%") unary }
+//	unary   = ("-" | "+") unary | primary
+//	primary = number | name | "(" expr ")"
+type parser struct {
+	tokens []token
+	pos    int
+	vars   map[string]int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != endToken {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorf(t token, format string, args ...any) error {
+	return fmt.Errorf("%w at column %d: %s", ErrSyntax, t.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) expr() (int, error) {
+	left, err := p.term()
+	if err != nil {
+		return 0, err
+	}
+	for t := p.peek(); t.text == "+" || t.text == "-"; t = p.peek() {
+		p.next()
+		right, err := p.term()
+		if err != nil {
+			return 0, err
+		}
+		if t.text == "+" {
+			left, err = mathutil.AddChecked(left, right)
+		} else {
+			left, err = mathutil.SubstractChecked(left, right)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) term() (int, error) {
+	left, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+	for t := p.peek(); t.text == "*" || t.text == "/" || t.text == "%"; t = p.peek() {
+		p.next()
+		right, err := p.unary()
+		if err != nil {
+			return 0, err
+		}
+		switch t.text {
+		case "*":
+			left, err = mathutil.MultiplyChecked(left, right)
+		case "/":
+			left, err = mathutil.Divide(left, right)
+		case "%":
+			left, err = mathutil.Mod(left, right)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (int, error) {
+	switch p.peek().text {
+	case "-":
+		p.next()
+		value, err := p.unary()
+		if err != nil {
+			return 0, err
+		}
+		return mathutil.SubstractChecked(0, value)
+	case "+":
+		p.next()
+		return p.unary()
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (int, error) {
+	t := p.next()
+	switch {
+	case t.kind == numberToken:
+		value, err := strconv.Atoi(t.text)
+		if err != nil {
+			return 0, fmt.Errorf("number %s: %w", t.text, mathutil.ErrOverflow)
+		}
+		return value, nil
+	case t.kind == identToken:
+		value, ok := p.vars[t.text]
+		if !ok {
+			return 0, fmt.Errorf("%w %q", ErrUndefinedVariable, t.text)
+		}
+		return value, nil
+	case t.text == "(":
+		value, err := p.expr()
+		if err != nil {
+			return 0, err
+		}
+		if closing := p.next(); closing.text != ")" {
+			return 0, p.errorf(closing, "expected \")\", got %q", closing.text)
+		}
+		return value, nil
+	}
+	return 0, p.errorf(t, "unexpected %q", t.text)
+}
@@ -0,0 +1,81 @@
+package calc
+
+import (
+	"errors"
+	"execise/checked"
+	"execise/mathutils"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+		err      error
+	}{
+		{"1 + 2", 3, nil},
+		{"(3 + 4) * 2 / 7", 2, nil},
+		{"2 + 3 * 4", 14, nil},
+		{"(2 + 3) * 4", 20, nil},
+		{"10 - 4 - 3", 3, nil},
+		{"100 / 10 / 5", 2, nil},
+		{"7 % 3 * 2", 2, nil},
+		{"-3 * -(2 + 1)", 9, nil},
+		{"--5", 5, nil},
+		{"+5 - -5", 10, nil},
+		{"-7 / 2", -3, nil},
+		{"  42  ", 42, nil},
+		{"1 / 0", 0, mathutils.ErrDiviedByZero},
+		{"1 % (2 - 2)", 0, mathutils.ErrDiviedByZero},
+		{"9223372036854775807 + 1", 0, checked.ErrOverflow},
+		{"99999999999999999999", 0, checked.ErrOverflow},
+		{"", 0, ErrSyntax},
+		{"1 +", 0, ErrSyntax},
+		{"(1 + 2", 0, ErrSyntax},
+		{"1 + 2)", 0, ErrSyntax},
+		{"2 3", 0, ErrSyntax},
+		{"2 ^ 3", 0, ErrSyntax},
+		{"y * 2", 0, ErrUndefinedVariable},
+	}
+
+	for _, test := range tests {
+		result, err := New().Eval(test.input)
+		if result != test.expected || !errors.Is(err, test.err) {
+			t.Errorf("Eval(%q) = %d, %v; want %d, %v", test.input, result, err, test.expected, test.err)
+		}
+	}
+}
+
+func TestEvalVariables(t *testing.T) {
+	c := New()
+	steps := []struct {
+		input    string
+		expected int
+		err      error
+	}{
+		{"x = 6", 6, nil},
+		{"y = x * 7", 42, nil},
+		{"x + y", 48, nil},
+		{"x = x - 1", 5, nil},
+		{"x", 5, nil},
+		{"z = 1 / 0", 0, mathutils.ErrDiviedByZero},
+		{"z", 0, ErrUndefinedVariable},
+		{"x = ", 0, ErrSyntax},
+		{"1 = 2", 0, ErrSyntax},
+	}
+
+	for _, step := range steps {
+		result, err := c.Eval(step.input)
+		if result != step.expected || !errors.Is(err, step.err) {
+			t.Errorf("Eval(%q) = %d, %v; want %d, %v", step.input, result, err, step.expected, step.err)
+		}
+	}
+}
+
+func BenchmarkEval(b *testing.B) {
+	c := New()
+	c.Vars["x"] = 3
+	for i := 0; i < b.N; i++ {
+		c.Eval("(x + 4) * 2 / 7 - -x % 2")
+	}
+}
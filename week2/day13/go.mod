@@ -1,3 +1,7 @@
 module day13
 
 go 1.23.5
+
+require execise v0.0.0-00010101000000-000000000000
+
+replace execise => ../day9/execise
@@ -1,20 +1,62 @@
 package main
 
 import (
+	"bufio"
+	"day13/calc"
 	"day13/mathutil"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 )
 
+// Usage:
+//
+//	go run . "(3 + 4) * 2 / 7"   evaluate one expression
+//	go run .                     start the REPL; "name = expr" assigns, "ans" is the last result
 func main() {
-	a, b := 10, 5
+	c := calc.New()
 
-	//log.Println("This is a log message")
+	if len(os.Args) > 1 {
+		result, err := c.Eval(strings.Join(os.Args[1:], " "))
+		if err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+		fmt.Println(result)
+		return
+	}
 
-	sum := mathutil.Add(a, b)
-	sub := mathutil.Substract(a, b)
+	scanner := bufio.NewScanner(os.Stdin)
+	fmt.Println("Calculator: enter an expression, \"name = expression\" to assign, or \"exit\" to quit.")
+	for {
+		fmt.Print("> ")
+		if !scanner.Scan() {
+			break
+		}
 
-	fmt.Printf("Add(%d, %d) = %d\n", a, b, sum)
-	fmt.Printf("Substract(%d, %d) = %d\n", a, b, sub)
+		line := strings.TrimSpace(scanner.Text())
+		switch line {
+		case "":
+			continue
+		case "exit", "quit":
+			return
+		}
 
-	//log.Println("End of the program")
+		result, err := c.Eval(line)
+		if err != nil {
+			printError(err)
+			continue
+		}
+		c.Vars["ans"] = result
+		fmt.Println(result)
+	}
+}
+
+func printError(err error) {
+	if errors.Is(err, mathutil.ErrDiviedByZero) {
+		fmt.Println("Error: division by zero:", err)
+		return
+	}
+	fmt.Println("Error:", err)
 }
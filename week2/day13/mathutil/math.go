@@ -1,9 +1,53 @@
+// Package mathutil provides basic integer arithmetic and descriptive statistics.
+//
+// Add and Substract wrap around on overflow like Go's operators. AddChecked,
+// SubstractChecked, MultiplyChecked, Divide and Mod go through the checked
+// package of the execise module and report overflow and division by zero as
+// errors wrapping ErrOverflow and ErrDiviedByZero instead.
 package mathutil
 
+import "execise/checked"
+
+// ErrOverflow and ErrDiviedByZero are the errors of checked and of
+// mathutils in the execise module, so errors.Is matches all of them.
+var (
+	ErrOverflow     = checked.ErrOverflow
+	ErrDiviedByZero = checked.ErrDivideByZero
+)
+
+// Add returns the sum of two numbers.
 func Add(a, b int) int {
 	return a + b
 }
 
+// Substract returns the difference of two numbers.
 func Substract(a, b int) int {
 	return a - b
 }
+
+// AddChecked returns the sum of two numbers, or an error if it overflows.
+func AddChecked(a, b int) (int, error) {
+	return checked.Add(a, b)
+}
+
+// SubstractChecked returns the difference of two numbers, or an error if it overflows.
+func SubstractChecked(a, b int) (int, error) {
+	return checked.Sub(a, b)
+}
+
+// MultiplyChecked returns the product of two numbers, or an error if it overflows.
+func MultiplyChecked(a, b int) (int, error) {
+	return checked.Mul(a, b)
+}
+
+// Divide returns the quotient of two numbers truncated toward zero, or an
+// error when dividing by zero or the quotient overflows.
+func Divide(a, b int) (int, error) {
+	return checked.Div(a, b)
+}
+
+// Mod returns the remainder of two numbers, which has the sign of a, or an
+// error when dividing by zero.
+func Mod(a, b int) (int, error) {
+	return checked.Mod(a, b)
+}
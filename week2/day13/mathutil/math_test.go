@@ -1,6 +1,12 @@
 package mathutil
 
-import "testing"
+import (
+	"errors"
+	"execise/checked"
+	"execise/mathutils"
+	"math"
+	"testing"
+)
 
 func TestAdd(t *testing.T) {
 	result := Add(2, 3)
@@ -36,6 +42,35 @@ func TestAddTableDriven(t *testing.T) {
 	}
 }
 
+func TestChecked(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func(a, b int) (int, error)
+		a, b     int
+		expected int
+		err      error
+	}{
+		{"AddChecked", AddChecked, 2, 3, 5, nil},
+		{"AddChecked", AddChecked, math.MaxInt, 1, 0, checked.ErrOverflow},
+		{"SubstractChecked", SubstractChecked, 10, 5, 5, nil},
+		{"SubstractChecked", SubstractChecked, math.MinInt, 1, 0, checked.ErrOverflow},
+		{"MultiplyChecked", MultiplyChecked, 6, -7, -42, nil},
+		{"MultiplyChecked", MultiplyChecked, math.MaxInt, 2, 0, ErrOverflow},
+		{"Divide", Divide, -7, 2, -3, nil},
+		{"Divide", Divide, 1, 0, 0, mathutils.ErrDiviedByZero},
+		{"Divide", Divide, math.MinInt, -1, 0, ErrOverflow},
+		{"Mod", Mod, -7, 2, -1, nil},
+		{"Mod", Mod, 1, 0, 0, ErrDiviedByZero},
+	}
+
+	for _, test := range tests {
+		result, err := test.fn(test.a, test.b)
+		if result != test.expected || !errors.Is(err, test.err) {
+			t.Errorf("%s(%d, %d) return %d, %v, want %d, %v", test.name, test.a, test.b, result, err, test.expected, test.err)
+		}
+	}
+}
+
 func BenchmarkAdd(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		Add(10, 20)
@@ -0,0 +1,249 @@
+package mathutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+var (
+	ErrEmpty           = errors.New("no data")
+	ErrTooFewValues    = errors.New("too few values")
+	ErrInvalidArgument = errors.New("invalid argument")
+)
+
+// Interpolation selects how Percentile picks a value between two data points.
+type Interpolation int
+
+const (
+	Linear   Interpolation = iota // weighted between the neighbours (Excel PERCENTILE.INC, NumPy default)
+	Lower                         // the lower neighbour
+	Higher                        // the higher neighbour
+	Nearest                       // the closer neighbour, ties to the even index
+	Midpoint                      // the mean of both neighbours
+)
+
+// Mean returns the arithmetic mean of data.
+func Mean(data []float64) (float64, error) {
+	if len(data) == 0 {
+		return 0, ErrEmpty
+	}
+	var acc Accumulator
+	for _, x := range data {
+		acc.Add(x)
+	}
+	return acc.Mean(), nil
+}
+
+// Median returns the middle value of data, or the mean of the two middle
+// values for an even count. data is not modified.
+func Median(data []float64) (float64, error) {
+	return Percentile(data, 50, Midpoint)
+}
+
+// Mode returns the most frequent values of data in increasing order.
+// Every value is a mode when all of them occur equally often.
+func Mode(data []float64) ([]float64, error) {
+	if len(data) == 0 {
+		return nil, ErrEmpty
+	}
+
+	counts := make(map[float64]int)
+	best := 0
+	for _, x := range data {
+		counts[x]++
+		best = max(best, counts[x])
+	}
+
+	var modes []float64
+	for x, n := range counts {
+		if n == best {
+			modes = append(modes, x)
+		}
+	}
+	sort.Float64s(modes)
+	return modes, nil
+}
+
+// Variance returns the population variance of data.
+func Variance(data []float64) (float64, error) {
+	if len(data) == 0 {
+		return 0, ErrEmpty
+	}
+	var acc Accumulator
+	for _, x := range data {
+		acc.Add(x)
+	}
+	return acc.Variance(), nil
+}
+
+// SampleVariance returns the sample variance of data (Bessel's correction).
+// It needs at least two values and returns ErrEmpty for none and
+// ErrTooFewValues for one.
+func SampleVariance(data []float64) (float64, error) {
+	if len(data) == 0 {
+		return 0, ErrEmpty
+	}
+	if len(data) < 2 {
+		return 0, fmt.Errorf("sample variance needs at least 2 values: %w", ErrTooFewValues)
+	}
+	var acc Accumulator
+	for _, x := range data {
+		acc.Add(x)
+	}
+	return acc.SampleVariance(), nil
+}
+
+// StdDev returns the population standard deviation of data.
+func StdDev(data []float64) (float64, error) {
+	v, err := Variance(data)
+	return math.Sqrt(v), err
+}
+
+// SampleStdDev returns the sample standard deviation of data.
+func SampleStdDev(data []float64) (float64, error) {
+	v, err := SampleVariance(data)
+	return math.Sqrt(v), err
+}
+
+// Percentile returns the p-th percentile (0 <= p <= 100) of data. When the
+// rank falls between two data points, interp decides the result.
+// data is not modified.
+func Percentile(data []float64, p float64, interp Interpolation) (float64, error) {
+	if len(data) == 0 {
+		return 0, ErrEmpty
+	}
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, fmt.Errorf("percentile %v not in [0, 100]: %w", p, ErrInvalidArgument)
+	}
+
+	sorted := append([]float64(nil), data...)
+	sort.Float64s(sorted)
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lo, hi := int(math.Floor(rank)), int(math.Ceil(rank))
+	frac := rank - float64(lo)
+
+	switch interp {
+	case Linear:
+		return sorted[lo] + frac*(sorted[hi]-sorted[lo]), nil
+	case Lower:
+		return sorted[lo], nil
+	case Higher:
+		return sorted[hi], nil
+	case Nearest:
+		if frac > 0.5 || (frac == 0.5 && lo%2 == 1) {
+			return sorted[hi], nil
+		}
+		return sorted[lo], nil
+	case Midpoint:
+		return (sorted[lo] + sorted[hi]) / 2, nil
+	}
+	return 0, fmt.Errorf("interpolation %d: %w", interp, ErrInvalidArgument)
+}
+
+// Accumulator computes count, mean, variance, min and max of a stream of
+// values in a single pass with Welford's algorithm, which stays numerically
+// stable where the naive sum of squares does not. The zero value is ready to use.
+type Accumulator struct {
+	n        int
+	mean, m2 float64
+	min, max float64
+}
+
+// Add adds x to the accumulator.
+func (a *Accumulator) Add(x float64) {
+	a.n++
+	if a.n == 1 {
+		a.min, a.max = x, x
+	} else {
+		a.min, a.max = min(a.min, x), max(a.max, x)
+	}
+
+	delta := x - a.mean
+	a.mean += delta / float64(a.n)
+	a.m2 += delta * (x - a.mean)
+}
+
+// Count returns the number of values added.
+func (a *Accumulator) Count() int {
+	return a.n
+}
+
+// Mean returns the mean of the values added, or 0 if there are none.
+func (a *Accumulator) Mean() float64 {
+	return a.mean
+}
+
+// Variance returns the population variance of the values added.
+func (a *Accumulator) Variance() float64 {
+	if a.n == 0 {
+		return 0
+	}
+	return a.m2 / float64(a.n)
+}
+
+// SampleVariance returns the sample variance of the values added,
+// or 0 for fewer than two values.
+func (a *Accumulator) SampleVariance() float64 {
+	if a.n < 2 {
+		return 0
+	}
+	return a.m2 / float64(a.n-1)
+}
+
+// StdDev returns the population standard deviation of the values added.
+func (a *Accumulator) StdDev() float64 {
+	return math.Sqrt(a.Variance())
+}
+
+// Min returns the smallest value added, or 0 if there are none.
+func (a *Accumulator) Min() float64 {
+	return a.min
+}
+
+// Max returns the largest value added, or 0 if there are none.
+func (a *Accumulator) Max() float64 {
+	return a.max
+}
+
+// Accumulate consumes values until the channel is closed and returns the
+// final accumulator.
+func Accumulate(values <-chan float64) Accumulator {
+	var acc Accumulator
+	for x := range values {
+		acc.Add(x)
+	}
+	return acc
+}
+
+// AccumulateStream consumes values and sends a snapshot of the accumulator
+// after every value, so dashboards can show running statistics. The returned
+// channel is closed once values is closed or ctx is cancelled, so a reader
+// that stops early must cancel ctx.
+func AccumulateStream(ctx context.Context, values <-chan float64) <-chan Accumulator {
+	out := make(chan Accumulator)
+	go func() {
+		defer close(out)
+		var acc Accumulator
+		for {
+			select {
+			case x, ok := <-values:
+				if !ok {
+					return
+				}
+				acc.Add(x)
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case out <- acc:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
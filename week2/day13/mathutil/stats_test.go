@@ -0,0 +1,238 @@
+package mathutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+// almostEqual compares floats with a relative tolerance.
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+func TestMeanMedianVariance(t *testing.T) {
+	tests := []struct {
+		data           []float64
+		mean, median   float64
+		variance, std  float64
+		sampleVariance float64
+	}{
+		{[]float64{5}, 5, 5, 0, 0, 0},
+		{[]float64{1, 2, 3, 4}, 2.5, 2.5, 1.25, math.Sqrt(1.25), 5.0 / 3},
+		{[]float64{2, 4, 4, 4, 5, 5, 7, 9}, 5, 4.5, 4, 2, 32.0 / 7},
+		{[]float64{3, 1, 2}, 2, 2, 2.0 / 3, math.Sqrt(2.0 / 3), 1},
+		{[]float64{-1.5, 0, 1.5}, 0, 0, 1.5, math.Sqrt(1.5), 2.25},
+	}
+
+	for _, test := range tests {
+		if result, err := Mean(test.data); err != nil || !almostEqual(result, test.mean) {
+			t.Errorf("Mean(%v) return %v, %v, want %v", test.data, result, err, test.mean)
+		}
+		if result, err := Median(test.data); err != nil || !almostEqual(result, test.median) {
+			t.Errorf("Median(%v) return %v, %v, want %v", test.data, result, err, test.median)
+		}
+		if result, err := Variance(test.data); err != nil || !almostEqual(result, test.variance) {
+			t.Errorf("Variance(%v) return %v, %v, want %v", test.data, result, err, test.variance)
+		}
+		if result, err := StdDev(test.data); err != nil || !almostEqual(result, test.std) {
+			t.Errorf("StdDev(%v) return %v, %v, want %v", test.data, result, err, test.std)
+		}
+		if len(test.data) > 1 {
+			if result, err := SampleVariance(test.data); err != nil || !almostEqual(result, test.sampleVariance) {
+				t.Errorf("SampleVariance(%v) return %v, %v, want %v", test.data, result, err, test.sampleVariance)
+			}
+		}
+	}
+}
+
+func TestMedianDoesNotModifyInput(t *testing.T) {
+	data := []float64{3, 1, 2}
+	Median(data)
+	if fmt.Sprint(data) != "[3 1 2]" {
+		t.Errorf("Median modified its input to %v", data)
+	}
+}
+
+func TestMode(t *testing.T) {
+	tests := []struct {
+		data     []float64
+		expected []float64
+	}{
+		{[]float64{1, 2, 2, 3}, []float64{2}},
+		{[]float64{4, 1, 4, 1, 3}, []float64{1, 4}},
+		{[]float64{3, 2, 1}, []float64{1, 2, 3}},
+	}
+
+	for _, test := range tests {
+		result, err := Mode(test.data)
+		if err != nil || fmt.Sprint(result) != fmt.Sprint(test.expected) {
+			t.Errorf("Mode(%v) return %v, %v, want %v", test.data, result, err, test.expected)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	data := []float64{15, 20, 35, 40, 50}
+	tests := []struct {
+		p        float64
+		interp   Interpolation
+		expected float64
+	}{
+		{0, Linear, 15},
+		{100, Linear, 50},
+		{50, Linear, 35},
+		{40, Linear, 29},
+		{40, Lower, 20},
+		{40, Higher, 35},
+		{40, Nearest, 35},
+		{40, Midpoint, 27.5},
+		{37.5, Nearest, 35}, // rank 1.5 ties to index 2
+		{62.5, Nearest, 35}, // rank 2.5 ties to index 2
+		{90, Linear, 46},
+	}
+
+	for _, test := range tests {
+		if result, err := Percentile(data, test.p, test.interp); err != nil || !almostEqual(result, test.expected) {
+			t.Errorf("Percentile(%v, %v, %d) return %v, %v, want %v", data, test.p, test.interp, result, err, test.expected)
+		}
+	}
+}
+
+func TestStatsErrors(t *testing.T) {
+	if _, err := Mean(nil); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Mean(nil) error %v, want ErrEmpty", err)
+	}
+	if _, err := Median(nil); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Median(nil) error %v, want ErrEmpty", err)
+	}
+	if _, err := Mode(nil); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Mode(nil) error %v, want ErrEmpty", err)
+	}
+	if _, err := SampleVariance(nil); !errors.Is(err, ErrEmpty) {
+		t.Errorf("SampleVariance(nil) error %v, want ErrEmpty", err)
+	}
+	if _, err := SampleVariance([]float64{1}); !errors.Is(err, ErrTooFewValues) {
+		t.Errorf("SampleVariance of one value error %v, want ErrTooFewValues", err)
+	}
+	if _, err := Percentile([]float64{1}, 101, Linear); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Percentile(101) error %v, want ErrInvalidArgument", err)
+	}
+	if _, err := Percentile([]float64{1, 2}, 50, Interpolation(99)); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Percentile with unknown interpolation error %v, want ErrInvalidArgument", err)
+	}
+}
+
+func TestAccumulatorStability(t *testing.T) {
+	// A large offset breaks the naive sum-of-squares formula, not Welford's.
+	var acc Accumulator
+	for _, x := range []float64{4, 7, 13, 16} {
+		acc.Add(1e9 + x)
+	}
+	if !almostEqual(acc.Variance(), 22.5) || acc.Count() != 4 {
+		t.Errorf("Variance with large offset return %v, want 22.5", acc.Variance())
+	}
+	if acc.Min() != 1e9+4 || acc.Max() != 1e9+16 {
+		t.Errorf("Min, Max return %v, %v, want %v, %v", acc.Min(), acc.Max(), 1e9+4, 1e9+16)
+	}
+}
+
+func TestAccumulate(t *testing.T) {
+	values := make(chan float64)
+	go func() {
+		for _, x := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+			values <- x
+		}
+		close(values)
+	}()
+
+	acc := Accumulate(values)
+	if acc.Count() != 8 || acc.Mean() != 5 || acc.StdDev() != 2 {
+		t.Errorf("Accumulate return count %d mean %v std %v, want 8, 5, 2", acc.Count(), acc.Mean(), acc.StdDev())
+	}
+}
+
+func TestAccumulateStream(t *testing.T) {
+	values := make(chan float64)
+	go func() {
+		for _, x := range []float64{1, 2, 3, 4} {
+			values <- x
+		}
+		close(values)
+	}()
+
+	expected := []float64{1, 1.5, 2, 2.5}
+	i := 0
+	for acc := range AccumulateStream(context.Background(), values) {
+		if acc.Count() != i+1 || acc.Mean() != expected[i] {
+			t.Errorf("snapshot %d return count %d mean %v, want %d, %v", i, acc.Count(), acc.Mean(), i+1, expected[i])
+		}
+		i++
+	}
+	if i != len(expected) {
+		t.Errorf("AccumulateStream sent %d snapshots, want %d", i, len(expected))
+	}
+}
+
+func TestAccumulateStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	values := make(chan float64)
+	stream := AccumulateStream(ctx, values)
+
+	values <- 1
+	cancel() // the snapshot for 1 is never read and values is never closed
+
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-stream:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("AccumulateStream did not stop after ctx was cancelled")
+		}
+	}
+}
+
+func benchmarkData() []float64 {
+	data := make([]float64, 10000)
+	for i := range data {
+		data[i] = float64((i * 7919) % 10007)
+	}
+	return data
+}
+
+func BenchmarkMean(b *testing.B) {
+	data := benchmarkData()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Mean(data)
+	}
+}
+
+func BenchmarkMedian(b *testing.B) {
+	data := benchmarkData()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Median(data)
+	}
+}
+
+func BenchmarkPercentile(b *testing.B) {
+	data := benchmarkData()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Percentile(data, 95, Linear)
+	}
+}
+
+func BenchmarkAccumulatorAdd(b *testing.B) {
+	var acc Accumulator
+	for i := 0; i < b.N; i++ {
+		acc.Add(float64(i))
+	}
+}
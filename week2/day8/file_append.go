@@ -1,28 +1,74 @@
+//go:build ignore
+
 // This code demonstrates to append content instead of overwriting, use os.OpenFile() with os.O_APPEND.
+// os.O_CREATE is needed as well, otherwise appending fails when the file does not exist yet;
+// fileutil.OpenAppend opens the file with both flags.
+// Instead of growing output.txt forever, rotate.Writer can roll it over by size (-max-size)
+// or by day (-daily), keep only -backups old files and gzip them (-compress).
 // Date: February 9, 2025
 
 package main
 
 import (
+	"day8/fileerr"
+	"day8/fileutil"
+	"day8/rotate"
+	"flag"
 	"fmt"
+	"mymodule/output"
 	"os"
+	"strconv"
 )
 
 func main() {
-	// Open file in append mode
-	file, err := os.OpenFile("output.txt", os.O_APPEND|os.O_WRONLY, 0644)
+	perm := flag.String("perm", strconv.FormatUint(uint64(fileutil.DefaultPerm), 8), "permission used if the file is created (octal)")
+	maxSize := flag.Int64("max-size", 0, "rotate before output.txt grows beyond this many bytes (0 disables)")
+	daily := flag.Bool("daily", false, "rotate on the first append of a new day")
+	backups := flag.Int("backups", 0, "number of rotated files to keep (0 keeps all)")
+	compress := flag.Bool("compress", false, "gzip rotated files")
+	var colorMode output.Mode
+	flag.Var(&colorMode, "color", output.FlagUsage)
+	flag.Parse()
+	out := output.New(os.Stdout, colorMode)
+	errOut := output.New(os.Stderr, colorMode)
+
+	mode, err := strconv.ParseUint(*perm, 8, 32)
 	if err != nil {
-		fmt.Println("Failed to open the file:", err)
-		return
+		errOut.Error("Invalid permission:", *perm)
+		os.Exit(2)
 	}
 
-	defer file.Close()
+	// Open file in append mode, creating it if missing.
+	// Compression and cleanup run in the background; Close waits for them,
+	// so rotateErr is safe to read afterwards.
+	var rotateErr error
+	file, err := rotate.New("output.txt", rotate.Options{
+		MaxSize:    *maxSize,
+		Daily:      *daily,
+		MaxBackups: *backups,
+		Compress:   *compress,
+		Perm:       os.FileMode(mode),
+		OnError: func(err error) {
+			errOut.Error("Rotation failed:", err)
+			rotateErr = err
+		},
+	})
+	if err != nil {
+		errOut.Error("Failed to open the file:", err)
+		os.Exit(fileerr.ExitCode(err))
+	}
 
-	_, err = file.WriteString("This is appended content.\n")
+	_, err = fmt.Fprint(file, "This is appended content.\n")
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
-		fmt.Println("Append failed:", err)
-		return
+		errOut.Error("Append failed:", err)
+		os.Exit(fileerr.ExitCode(err))
+	}
+	if rotateErr != nil {
+		os.Exit(fileerr.ExitCode(rotateErr))
 	}
 
-	fmt.Println("Append successful!")
+	out.Success("Append successful!")
 }
@@ -1,41 +1,112 @@
+//go:build ignore
+
 // This code demonstrates how to count the number of lines in a file.
+// It works like a small `wc`: given files or directories it walks them recursively,
+// counts lines, words and bytes of every file concurrently, and prints per-file and total results.
+//
+// Lines of any length are handled, binary files are detected and reported, skipped or counted (-binary).
+// If a file cannot be counted, it exits with the day8/fileerr exit code of the first such error.
+//
+// Usage: go run file_countlines.go [-include glob] [-exclude glob] [-workers N] [-binary report|skip|count] [-color auto|always|never] [path ...]
 // Date: February 9, 2025
 
 package main
 
 import (
-	"bufio"
+	"day8/fileerr"
+	"day8/walk"
+	"day8/wc"
+	"errors"
+	"flag"
 	"fmt"
+	"mymodule/output"
 	"os"
+	"runtime"
+	"sync"
 )
 
-func countLines(filename string) (int, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return 0, err
-	}
-	defer file.Close()
+type fileResult struct {
+	counts wc.Counts
+	err    error
+}
+
+// countFiles counts every file on a pool of workers goroutines.
+// Results are returned in the same order as files.
+func countFiles(files []string, workers int, countBinary bool) []fileResult {
+	results := make([]fileResult, len(files))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
 
-	scanner := bufio.NewScanner(file)
-	lineCount := 0
-	for scanner.Scan() {
-		lineCount++
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				c, err := wc.CountFile(files[idx], countBinary)
+				results[idx] = fileResult{counts: c, err: err}
+			}
+		}()
 	}
 
-	if err = scanner.Err(); err != nil {
-		return 0, err
+	for idx := range files {
+		jobs <- idx
 	}
+	close(jobs)
+	wg.Wait()
 
-	return lineCount, nil
+	return results
 }
 
 func main() {
-	filename := "sample.txt"
-	lines, err := countLines(filename)
-	if err != nil {
-		fmt.Println("Error:", err)
-		return
+	var include, exclude walk.Patterns
+	flag.Var(&include, "include", "only count files whose name matches this glob (repeatable)")
+	flag.Var(&exclude, "exclude", "skip files and directories whose name matches this glob (repeatable)")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of files counted concurrently")
+	binary := flag.String("binary", "report", "how to treat binary files: report, skip or count")
+	var colorMode output.Mode
+	flag.Var(&colorMode, "color", output.FlagUsage)
+	flag.Parse()
+	out := output.New(os.Stdout, colorMode)
+
+	if *binary != "report" && *binary != "skip" && *binary != "count" {
+		out.Error("Error: -binary must be report, skip or count")
+		os.Exit(2)
+	}
+
+	roots := flag.Args()
+	if len(roots) == 0 {
+		roots = []string{"sample.txt"}
+	}
+	if *workers < 1 {
+		*workers = 1
+	}
+
+	files, errs := walk.Files(roots, include, exclude)
+	for _, err := range errs {
+		out.Error("Error:", err)
 	}
 
-	fmt.Printf("File %s has %d lines\n", filename, lines)
+	var total wc.Counts
+	for i, result := range countFiles(files, *workers, *binary == "count") {
+		if errors.Is(result.err, wc.ErrBinary) {
+			if *binary == "report" {
+				out.Printf(output.Muted, "%8s %8s %8d %s (binary, skipped)\n", "-", "-", result.counts.Bytes, files[i])
+			}
+			continue
+		}
+		if result.err != nil {
+			out.Error("Error:", result.err)
+			errs = append(errs, result.err)
+			continue
+		}
+		total.Add(result.counts)
+		fmt.Printf("%8d %8d %8d %s\n", result.counts.Lines, result.counts.Words, result.counts.Bytes, files[i])
+	}
+	if len(files) > 1 {
+		fmt.Printf("%8d %8d %8d total\n", total.Lines, total.Words, total.Bytes)
+	}
+
+	if len(errs) > 0 {
+		os.Exit(fileerr.ExitCode(errs[0]))
+	}
 }
@@ -1,23 +1,56 @@
+//go:build ignore
+
 // This code demonstrate how to handle file errors in Go.
+// fileerr.Classify maps an error onto a class (not found, permission, is-a-directory,
+// too many open files, disk full, read-only file system, path too long) using errors.Is/errors.As,
+// and each class has its own exit code.
+//
+// Usage: go run file_error.go [-color auto|always|never] [file]
 // Date: February 9, 2025
 
 package main
 
 import (
-	"fmt"
+	"day8/fileerr"
+	"flag"
+	"io"
+	"mymodule/output"
 	"os"
 )
 
 func main() {
-	_, err := os.Open("not_exist.txt")
-	if err != nil {
-		if os.IsNotExist(err) {
-			fmt.Println("File does not exist")
-		} else if os.IsPermission(err) {
-			fmt.Println("Permission denied")
-		} else {
-			fmt.Println("Error:", err)
+	var colorMode output.Mode
+	flag.Var(&colorMode, "color", output.FlagUsage)
+	flag.Parse()
+	out := output.New(os.Stdout, colorMode)
+
+	filename := "not_exist.txt"
+	if flag.NArg() > 0 {
+		filename = flag.Arg(0)
+	}
+
+	file, err := os.Open(filename)
+	if err == nil {
+		// Reading reveals errors such as opening a directory
+		if _, err = file.Read(make([]byte, 1)); err == io.EOF {
+			err = nil
 		}
+		file.Close()
+	}
 
+	if e := fileerr.Classify(err); e != nil {
+		switch e.Class {
+		case fileerr.NotFound:
+			out.Error("File does not exist:", e.Path)
+		case fileerr.Permission:
+			out.Error("Permission denied:", e.Path)
+		case fileerr.Unknown:
+			out.Error("Error:", err)
+		default:
+			out.Error("Error:", e)
+		}
+		os.Exit(e.Class.ExitCode())
 	}
+
+	out.Success("File is readable:", filename)
 }
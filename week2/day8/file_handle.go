@@ -1,3 +1,5 @@
+//go:build ignore
+
 // This file contains the code for file handling in Go.
 // Golang uses os.Open() to open files and "bufio" or "ioutil" to read their contents.
 // Date: February 9, 2025
@@ -9,20 +11,57 @@
 // scanner.Scan() moves to the next line, and scanner.Text() gets the line’s content.
 // defer file.Close() ensures the file is closed after usage.
 
+// With -n N only the last N lines are printed (found by seeking backwards from the end),
+// and with -f the program keeps printing lines appended to the file until Ctrl+C,
+// following the file across truncation and log rotation. Both are handled by the day8/tail package.
+//
+// Usage: go run file_handle.go [-n N] [-f] [-color auto|always|never] [file]
+
 package main
 
 import (
 	"bufio"
+	"context"
+	"day8/fileerr"
+	"day8/tail"
+	"errors"
+	"flag"
 	"fmt"
+	"mymodule/output"
 	"os"
+	"os/signal"
 )
 
 func main() {
+	lines := flag.Int("n", 0, "print only the last `N` lines")
+	follow := flag.Bool("f", false, "keep printing lines appended to the file")
+	var colorMode output.Mode
+	flag.Var(&colorMode, "color", output.FlagUsage)
+	flag.Parse()
+	out := output.New(os.Stdout, colorMode)
+
+	filename := "sample.txt"
+	if flag.NArg() > 0 {
+		filename = flag.Arg(0)
+	}
+
+	if *lines > 0 || *follow {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		err := tail.Tail(ctx, filename, os.Stdout, tail.Options{Lines: *lines, Follow: *follow})
+		if err != nil && !errors.Is(err, context.Canceled) {
+			out.Error("Failed to read the file:", err)
+			os.Exit(fileerr.ExitCode(err))
+		}
+		return
+	}
+
 	// open the file
-	file, err := os.Open("sample.txt")
+	file, err := os.Open(filename)
 	if err != nil {
-		fmt.Println("Failed to open the file:", err)
-		return
+		out.Error("Failed to open the file:", err)
+		os.Exit(fileerr.ExitCode(err))
 	}
 	defer file.Close() // Ensure the file is colosed when the function exists
 
@@ -34,6 +73,8 @@ func main() {
 
 	// check for errors during reading
 	if err := scanner.Err(); err != nil {
-		fmt.Println("Error while reading file:", err)
+		out.Error("Error while reading file:", err)
+		file.Close()
+		os.Exit(fileerr.ExitCode(err))
 	}
 }
@@ -1,48 +1,281 @@
+//go:build ignore
+
 // Purpose: Go program to search for a keyword in a file.
+// The matching itself lives in the day8/search package; this file is the command line front end.
+// It works like a small grep: the keyword can be a regular expression (-regexp),
+// matched case-insensitively (-i) or as a whole word (-w), inverted (-v),
+// and printed with context lines (-A, -B, -C) and the columns of every match.
+// Files and directories (searched recursively) are processed concurrently by
+// -workers goroutines, and results are printed as text or as JSON lines (-json).
+// On a terminal matches are highlighted; -color=always|never overrides that.
+// It exits with 1 if nothing matched and, if a file could not be searched,
+// with the day8/fileerr exit code of the first such error.
+//
+// Usage: go run file_keyword_search.go [flags] [keyword] [file|dir ...]
 // Date: February 9, 2025
 
 package main
 
 import (
 	"bufio"
+	"day8/fileerr"
+	"day8/search"
+	"day8/walk"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
+	"mymodule/output"
 	"os"
+	"runtime"
+	"strconv"
 	"strings"
 )
 
-func searchKeyword(filename, keyword string) (bool, error) {
+// outputOptions controls how printMatches prints matches.
+type outputOptions struct {
+	json     bool // print one JSON object per match instead of text
+	showFile bool // prefix text output with the file name
+	context  bool // context lines were requested, so separate groups with "--"
+	colors   *output.Printer
+}
+
+// jsonMatch is a single match in -json output.
+type jsonMatch struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	Match  string `json:"match"`
+}
+
+// searchFile returns the matches of opts in filename.
+func searchFile(filename string, opts search.SearchOptions) ([]search.Match, error) {
 	file, err := os.Open(filename)
 	if err != nil {
-		fmt.Println("Open file failed:", err)
-		return false, err
+		return nil, err
 	}
 	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	totalCount := 0
-	lineCount := 1
-	for scanner.Scan() {
-		line := scanner.Text()
-		//fmt.Printf("Line %d: %s\n", lineCount, line) // Debug: Print each line
-		if count := strings.Count(line, keyword); count > 0 {
-			totalCount += count
-			fmt.Printf("%s found in line %d\n", keyword, lineCount)
+	return search.Search(file, opts)
+}
+
+// printMatches writes the matches of filename to w, in the form
+// "line:col,col: text", with "line- text" for context lines and "--" between
+// groups that are not adjacent; with out.json it writes one jsonMatch per match
+// (or per selected line when inverted) instead.
+// It returns the first error writing to w.
+func printMatches(w io.Writer, filename string, matches []search.Match, opts search.SearchOptions, out outputOptions) error {
+	if out.json {
+		encoder := json.NewEncoder(w)
+		for _, m := range matches {
+			if opts.Invert {
+				if err := encoder.Encode(jsonMatch{File: filename, Line: m.Number, Column: 1, Match: m.Text}); err != nil {
+					return err
+				}
+			}
+			for _, span := range m.Spans {
+				if err := encoder.Encode(jsonMatch{File: filename, Line: m.Number, Column: span.Start + 1, Match: m.Text[span.Start:span.End]}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	prefix := ""
+	if out.showFile {
+		prefix = filename + ":"
+	}
+	var err error
+	lastPrinted := 0
+	printLine := func(number int, tag, text string) {
+		if err != nil {
+			return
+		}
+		if out.context && lastPrinted > 0 && number > lastPrinted+1 {
+			if _, err = fmt.Fprintln(w, out.colors.Sprint(output.Muted, "--")); err != nil {
+				return
+			}
+		}
+		_, err = fmt.Fprintf(w, "%s %s\n", out.colors.Sprintf(output.Muted, "%s%d%s", prefix, number, tag), text)
+		lastPrinted = number
+	}
+	printContext := func(line search.Line) {
+		printLine(line.Number, "-", out.colors.Sprint(output.Muted, line.Text))
+	}
+
+	for _, m := range matches {
+		for _, line := range m.Before {
+			printContext(line)
+		}
+
+		cols := make([]string, len(m.Spans))
+		for i, span := range m.Spans {
+			cols[i] = strconv.Itoa(span.Start + 1)
+		}
+		tag := ":"
+		if len(cols) > 0 {
+			tag = ":" + strings.Join(cols, ",") + ":"
+		}
+		printLine(m.Number, tag, highlight(out.colors, m.Text, m.Spans))
+
+		for _, line := range m.After {
+			printContext(line)
 		}
-		lineCount++
 	}
-	fmt.Printf("Total occurrences of %s: %d\n", keyword, totalCount)
 
-	return true, nil
+	return err
+}
+
+// highlight returns text with every span in the Highlight style.
+func highlight(colors *output.Printer, text string, spans []search.Span) string {
+	if !colors.Enabled() || len(spans) == 0 {
+		return text
+	}
+	var sb strings.Builder
+	last := 0
+	for _, span := range spans {
+		sb.WriteString(text[last:span.Start])
+		sb.WriteString(colors.Sprint(output.Highlight, text[span.Start:span.End]))
+		last = span.End
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+type fileResult struct {
+	filename string
+	matches  []search.Match
+	err      error
+}
+
+// searchFiles searches every file on a pool of workers goroutines and sends
+// the results in the order of files, so they can be printed as soon as they
+// are ready without lines of different files interleaving. Only matches are
+// kept, not formatted output, and at most about workers files wait to be
+// printed at once.
+func searchFiles(files []string, opts search.SearchOptions, workers int) <-chan fileResult {
+	type job struct {
+		filename string
+		out      chan fileResult
+	}
+
+	jobs := make(chan job)
+	pending := make(chan chan fileResult, workers)
+	results := make(chan fileResult)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for j := range jobs {
+				matches, err := searchFile(j.filename, opts)
+				j.out <- fileResult{filename: j.filename, matches: matches, err: err}
+			}
+		}()
+	}
+
+	// Dispatch files and queue each file's result slot in order.
+	go func() {
+		for _, filename := range files {
+			out := make(chan fileResult, 1)
+			jobs <- job{filename: filename, out: out}
+			pending <- out
+		}
+		close(jobs)
+		close(pending)
+	}()
+
+	go func() {
+		for out := range pending {
+			results <- <-out
+		}
+		close(results)
+	}()
+
+	return results
 }
 
 func main() {
-	filename := "sample.txt"
-	fmt.Println("Enter the keyword to search: ")
+	useRegexp := flag.Bool("regexp", false, "treat the keyword as a regular expression")
+	ignoreCase := flag.Bool("i", false, "ignore case")
+	wholeWord := flag.Bool("w", false, "match whole words only")
+	invert := flag.Bool("v", false, "select lines that do not match")
+	after := flag.Int("A", 0, "print `N` lines of context after each match")
+	before := flag.Int("B", 0, "print `N` lines of context before each match")
+	context := flag.Int("C", 0, "print `N` lines of context around each match")
+	jsonOutput := flag.Bool("json", false, "print matches as JSON lines")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of files searched concurrently")
+	var colorMode output.Mode
+	flag.Var(&colorMode, "color", output.FlagUsage)
+	flag.Parse()
+	errOut := output.New(os.Stderr, colorMode)
+
 	var keyword string
-	fmt.Scanln(&keyword)
-	//fmt.Printf("Search for keyword: %s\n", keyword) // Debug: Print the keyword
-	if _, err := searchKeyword(filename, keyword); err != nil {
-		fmt.Println("Error:", err)
-		return
+	if flag.NArg() > 0 {
+		keyword = flag.Arg(0)
+	} else {
+		fmt.Println("Enter the keyword to search: ")
+		fmt.Scanln(&keyword)
+	}
+
+	paths := []string{"sample.txt"}
+	if flag.NArg() > 1 {
+		paths = flag.Args()[1:]
+	}
+
+	opts := search.SearchOptions{
+		Keyword:    keyword,
+		Regexp:     *useRegexp,
+		IgnoreCase: *ignoreCase,
+		WholeWord:  *wholeWord,
+		Invert:     *invert,
+		Before:     max(*before, *context),
+		After:      max(*after, *context),
+	}
+	if _, err := search.Compile(opts); err != nil {
+		errOut.Error("Error:", err)
+		os.Exit(2)
+	}
+
+	files, errs := walk.Files(paths, nil, nil)
+	for _, err := range errs {
+		errOut.Error("Error:", err)
+	}
+
+	out := outputOptions{
+		json:     *jsonOutput,
+		showFile: len(files) > 1,
+		context:  opts.Before > 0 || opts.After > 0,
+		colors:   output.New(os.Stdout, colorMode),
+	}
+
+	stdout := bufio.NewWriter(os.Stdout)
+	total := 0
+	for result := range searchFiles(files, opts, max(*workers, 1)) {
+		if result.err == nil {
+			result.err = printMatches(stdout, result.filename, result.matches, opts, out)
+		}
+		if result.err == nil {
+			result.err = stdout.Flush()
+		}
+		if result.err != nil {
+			errOut.Error("Error:", result.err)
+			errs = append(errs, result.err)
+		}
+		total += search.Count(result.matches, opts)
+	}
+
+	if !out.json {
+		if opts.Invert {
+			fmt.Printf("Total lines without %s: %d\n", keyword, total)
+		} else {
+			fmt.Printf("Total occurrences of %s: %d\n", keyword, total)
+		}
+	}
+
+	if len(errs) > 0 {
+		os.Exit(fileerr.ExitCode(errs[0]))
+	}
+	if total == 0 {
+		os.Exit(1)
 	}
 }
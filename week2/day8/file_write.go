@@ -1,29 +1,40 @@
+//go:build ignore
+
 // This code demonstrates that os.Create() creates or overwrites a file, while os.OpenFile() is used for appending content.
+// os.Create() truncates the file in place, so a crash mid-write leaves it half written.
+// fileutil.WriteFileAtomic writes a temporary file, syncs it and renames it over the target instead.
 // Date: February 9, 2025
 
 package main
 
 import (
-	"fmt"
+	"day8/fileerr"
+	"day8/fileutil"
+	"flag"
+	"mymodule/output"
 	"os"
+	"strconv"
 )
 
 func main() {
-	// Create and write to a file
-	file, err := os.Create("output.txt")
+	perm := flag.String("perm", strconv.FormatUint(uint64(fileutil.DefaultPerm), 8), "permission of the written file (octal)")
+	var colorMode output.Mode
+	flag.Var(&colorMode, "color", output.FlagUsage)
+	flag.Parse()
+	out := output.New(os.Stdout, colorMode)
+
+	mode, err := strconv.ParseUint(*perm, 8, 32)
 	if err != nil {
-		fmt.Println("Failed to create the file:", err)
-		return
+		out.Error("Invalid permission:", *perm)
+		os.Exit(2)
 	}
-	defer file.Close()
 
-	// write contest
-	_, err = file.WriteString("Hello, Golang!\n")
+	// Create and write to a file atomically
+	err = fileutil.WriteFileAtomic("output.txt", []byte("Hello, Golang!\n"), os.FileMode(mode))
 	if err != nil {
-		fmt.Println("Write failed:", err)
-		return
+		out.Error("Write failed:", err)
+		os.Exit(fileerr.ExitCode(err))
 	}
 
-	fmt.Println("Write successfule!")
-
+	out.Success("Write successfule!")
 }
@@ -0,0 +1,163 @@
+// Package fileerr classifies file system errors into a small set of classes,
+// keeping the failed operation and path, and maps each class to an exit code.
+package fileerr
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// Class is the kind of a file system error.
+type Class int
+
+const (
+	Unknown Class = iota
+	NotFound
+	Permission
+	IsDirectory
+	TooManyOpenFiles
+	DiskFull
+	ReadOnlyFS
+	PathTooLong
+)
+
+var classNames = map[Class]string{
+	Unknown:          "unknown error",
+	NotFound:         "file does not exist",
+	Permission:       "permission denied",
+	IsDirectory:      "is a directory",
+	TooManyOpenFiles: "too many open files",
+	DiskFull:         "no space left on device",
+	ReadOnlyFS:       "read-only file system",
+	PathTooLong:      "path too long",
+}
+
+func (c Class) String() string {
+	if name, ok := classNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("Class(%d)", int(c))
+}
+
+// ExitCode returns the process exit status for the class, following the
+// BSD sysexits.h conventions.
+func (c Class) ExitCode() int {
+	switch c {
+	case NotFound, IsDirectory:
+		return 66 // EX_NOINPUT
+	case Permission:
+		return 77 // EX_NOPERM
+	case TooManyOpenFiles:
+		return 75 // EX_TEMPFAIL
+	case DiskFull:
+		return 74 // EX_IOERR
+	case ReadOnlyFS:
+		return 73 // EX_CANTCREAT
+	case PathTooLong:
+		return 64 // EX_USAGE
+	}
+	return 1
+}
+
+// Error is a classified file system error.
+type Error struct {
+	Class Class
+	Op    string // failed operation, such as "open", if known
+	Path  string // offending path, if known
+	Err   error  // the original error
+}
+
+// Error returns the class, prefixed with the operation and path if known and
+// followed by the cause when it says more than the class name.
+func (e *Error) Error() string {
+	msg := e.Class.String()
+	if cause := e.cause(); cause != "" && cause != msg {
+		msg += ": " + cause
+	}
+	switch {
+	case e.Op != "" && e.Path != "":
+		return fmt.Sprintf("%s %s: %s", e.Op, e.Path, msg)
+	case e.Path != "":
+		return fmt.Sprintf("%s: %s", e.Path, msg)
+	}
+	return msg
+}
+
+// cause returns the text of the original error without the operation and
+// path that Error already shows.
+func (e *Error) cause() string {
+	err := e.Err
+	var pathErr *fs.PathError
+	var linkErr *os.LinkError
+	var syscallErr *os.SyscallError
+	switch {
+	case errors.As(err, &pathErr):
+		err = pathErr.Err
+	case errors.As(err, &linkErr):
+		err = linkErr.Err
+	case errors.As(err, &syscallErr):
+		err = syscallErr.Err
+	}
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Classify wraps err in an *Error describing its class, operation and path.
+// It returns nil if err is nil.
+func Classify(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	e := &Error{Class: classOf(err), Err: err}
+
+	var pathErr *fs.PathError
+	var linkErr *os.LinkError
+	var syscallErr *os.SyscallError
+	switch {
+	case errors.As(err, &pathErr):
+		e.Op, e.Path = pathErr.Op, pathErr.Path
+	case errors.As(err, &linkErr):
+		e.Op, e.Path = linkErr.Op, linkErr.Old
+	case errors.As(err, &syscallErr):
+		e.Op = syscallErr.Syscall
+	}
+	return e
+}
+
+// ExitCode returns 0 for a nil error and the exit code of err's class otherwise.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return Classify(err).Class.ExitCode()
+}
+
+func classOf(err error) Class {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return NotFound
+	case errors.Is(err, fs.ErrPermission):
+		return Permission
+	case errors.Is(err, syscall.EISDIR):
+		return IsDirectory
+	case errors.Is(err, syscall.EMFILE), errors.Is(err, syscall.ENFILE):
+		return TooManyOpenFiles
+	case errors.Is(err, syscall.ENOSPC), errors.Is(err, syscall.EDQUOT):
+		return DiskFull
+	case errors.Is(err, syscall.EROFS):
+		return ReadOnlyFS
+	case errors.Is(err, syscall.ENAMETOOLONG):
+		return PathTooLong
+	}
+	return Unknown
+}
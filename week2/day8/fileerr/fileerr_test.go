@@ -0,0 +1,97 @@
+package fileerr
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	pathErr := func(errno error) error {
+		return &fs.PathError{Op: "open", Path: "output.txt", Err: errno}
+	}
+
+	tests := []struct {
+		err      error
+		class    Class
+		op, path string
+		exitCode int
+	}{
+		{pathErr(syscall.ENOENT), NotFound, "open", "output.txt", 66},
+		{pathErr(syscall.EACCES), Permission, "open", "output.txt", 77},
+		{pathErr(syscall.EPERM), Permission, "open", "output.txt", 77},
+		{pathErr(syscall.EISDIR), IsDirectory, "open", "output.txt", 66},
+		{pathErr(syscall.EMFILE), TooManyOpenFiles, "open", "output.txt", 75},
+		{pathErr(syscall.ENFILE), TooManyOpenFiles, "open", "output.txt", 75},
+		{pathErr(syscall.ENOSPC), DiskFull, "open", "output.txt", 74},
+		{pathErr(syscall.EDQUOT), DiskFull, "open", "output.txt", 74},
+		{pathErr(syscall.EROFS), ReadOnlyFS, "open", "output.txt", 73},
+		{pathErr(syscall.ENAMETOOLONG), PathTooLong, "open", "output.txt", 64},
+		{fmt.Errorf("saving tasks: %w", pathErr(syscall.ENOSPC)), DiskFull, "open", "output.txt", 74},
+		{&os.LinkError{Op: "rename", Old: "a.tmp", New: "a", Err: syscall.EROFS}, ReadOnlyFS, "rename", "a.tmp", 73},
+		{os.NewSyscallError("fsync", syscall.ENOSPC), DiskFull, "fsync", "", 74},
+		{fs.ErrNotExist, NotFound, "", "", 66},
+		{errors.New("something else"), Unknown, "", "", 1},
+	}
+
+	for _, test := range tests {
+		e := Classify(test.err)
+		if e.Class != test.class || e.Op != test.op || e.Path != test.path {
+			t.Errorf("Classify(%v) = {%v %q %q}; want {%v %q %q}", test.err, e.Class, e.Op, e.Path, test.class, test.op, test.path)
+		}
+		if !errors.Is(e, test.err) {
+			t.Errorf("Classify(%v) does not wrap the original error", test.err)
+		}
+		if code := ExitCode(test.err); code != test.exitCode {
+			t.Errorf("ExitCode(%v) = %d; want %d", test.err, code, test.exitCode)
+		}
+	}
+}
+
+func TestClassifyNil(t *testing.T) {
+	if e := Classify(nil); e != nil {
+		t.Errorf("Classify(nil) = %v; want nil", e)
+	}
+	if code := ExitCode(nil); code != 0 {
+		t.Errorf("ExitCode(nil) = %d; want 0", code)
+	}
+}
+
+func TestClassifyRealErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := os.Open(filepath.Join(dir, "not_exist.txt"))
+	if e := Classify(err); e.Class != NotFound || e.Op != "open" {
+		t.Errorf("opening a missing file: got %v (%v); want %v", e.Class, err, NotFound)
+	}
+
+	_, err = os.ReadFile(filepath.Join(dir, strings.Repeat("x", 4096)))
+	if e := Classify(err); e.Class != PathTooLong && e.Class != NotFound {
+		t.Errorf("opening an over-long path: got %v (%v); want %v", e.Class, err, PathTooLong)
+	}
+}
+
+func TestErrorString(t *testing.T) {
+	tests := []struct {
+		err      *Error
+		expected string
+	}{
+		{&Error{Class: NotFound, Op: "open", Path: "a.txt"}, "open a.txt: file does not exist"},
+		{&Error{Class: DiskFull, Path: "a.txt"}, "a.txt: no space left on device"},
+		{&Error{Class: Unknown, Err: errors.New("boom")}, "unknown error: boom"},
+		{Classify(&fs.PathError{Op: "read", Path: "x", Err: syscall.EIO}), "read x: unknown error: " + syscall.EIO.Error()},
+		{Classify(&fs.PathError{Op: "open", Path: "a.txt", Err: syscall.EACCES}), "open a.txt: permission denied"},
+		{Classify(&os.LinkError{Op: "rename", Old: "a", New: "b", Err: syscall.EXDEV}), "rename a: unknown error: " + syscall.EXDEV.Error()},
+	}
+
+	for _, test := range tests {
+		if result := test.err.Error(); result != test.expected {
+			t.Errorf("Error() = %q; want %q", result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Package fileutil provides safer building blocks for writing files:
+// atomic replacement and create-if-missing appends.
+package fileutil
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// DefaultPerm is a sensible permission for new files (rw-r--r--), for callers
+// that have no reason to pick another one.
+const DefaultPerm os.FileMode = 0644
+
+// WriteFileAtomic replaces filename with data so that readers see either the
+// old content or the new content, never a partial write. The data goes to a
+// temporary file in the same directory, is synced to disk and then renamed
+// over filename.
+func WriteFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	return WriteAtomic(filename, perm, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// WriteAtomic is like WriteFileAtomic but lets write stream the content.
+// If write returns an error the original file is left untouched.
+func WriteAtomic(filename string, perm os.FileMode, write func(w io.Writer) error) (err error) {
+	dir, base := filepath.Split(filename)
+	if dir == "" {
+		dir = "."
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+base+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if err = write(tmp); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), filename); err != nil {
+		return err
+	}
+
+	syncDir(dir)
+	return nil
+}
+
+// OpenAppend opens filename for appending, creating it with perm if it does not exist.
+func OpenAppend(filename string, perm os.FileMode) (*os.File, error) {
+	return os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, perm)
+}
+
+// AppendFile appends data to filename, creating it with perm if it does not exist.
+func AppendFile(filename string, data []byte, perm os.FileMode) error {
+	file, err := OpenAppend(filename, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// syncDir flushes the directory entry so a completed rename survives a crash.
+// Not every platform supports syncing directories, and the rename has already
+// happened, so failures are ignored.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	d.Sync()
+	d.Close()
+}
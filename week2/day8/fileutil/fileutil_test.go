@@ -0,0 +1,97 @@
+package fileutil
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "output.txt")
+
+	tests := []struct {
+		data string
+		perm os.FileMode
+	}{
+		{"Hello, Golang!\n", 0644},
+		{"replaced\n", 0600},
+		{"", 0640},
+	}
+
+	for _, test := range tests {
+		if err := WriteFileAtomic(filename, []byte(test.data), test.perm); err != nil {
+			t.Fatalf("WriteFileAtomic(%q) returned error %v", test.data, err)
+		}
+
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatalf("ReadFile returned error %v", err)
+		}
+		if string(content) != test.data {
+			t.Errorf("content = %q; want %q", content, test.data)
+		}
+
+		info, err := os.Stat(filename)
+		if err != nil {
+			t.Fatalf("Stat returned error %v", err)
+		}
+		if info.Mode().Perm() != test.perm {
+			t.Errorf("perm = %v; want %v", info.Mode().Perm(), test.perm)
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries; want only output.txt", len(entries))
+	}
+}
+
+func TestWriteAtomicFailureKeepsOriginal(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "output.txt")
+	if err := os.WriteFile(filename, []byte("original\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	errWrite := errors.New("write failed")
+	err := WriteAtomic(filename, 0644, func(w io.Writer) error {
+		io.WriteString(w, "partial")
+		return errWrite
+	})
+	if !errors.Is(err, errWrite) {
+		t.Errorf("WriteAtomic returned %v; want %v", err, errWrite)
+	}
+
+	content, _ := os.ReadFile(filename)
+	if string(content) != "original\n" {
+		t.Errorf("content = %q; want original content", content)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries; temporary file was not removed", len(entries))
+	}
+}
+
+func TestAppendFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "output.txt")
+
+	for _, line := range []string{"first\n", "second\n"} {
+		if err := AppendFile(filename, []byte(line), 0600); err != nil {
+			t.Fatalf("AppendFile(%q) returned error %v", line, err)
+		}
+	}
+
+	content, _ := os.ReadFile(filename)
+	if string(content) != "first\nsecond\n" {
+		t.Errorf("content = %q; want %q", content, "first\nsecond\n")
+	}
+
+	info, _ := os.Stat(filename)
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("perm = %v; want %v", info.Mode().Perm(), os.FileMode(0600))
+	}
+}
@@ -0,0 +1,282 @@
+// Package rotate provides an io.Writer that appends to a file and rolls it
+// over by size or by day, keeping a limited number of optionally gzipped backups.
+package rotate
+
+import (
+	"compress/gzip"
+	"day8/fileutil"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat names backups so that sorting by name sorts by age.
+const backupTimeFormat = "20060102T150405.000"
+
+// Options controls when a Writer rotates and what it keeps.
+type Options struct {
+	MaxSize    int64       // rotate before the file would grow beyond MaxSize bytes; 0 disables
+	Daily      bool        // rotate on the first write of a new day
+	MaxBackups int         // number of rotated files to keep; 0 keeps all
+	Compress   bool        // gzip rotated files
+	Perm       os.FileMode // permission of new files; fileutil.DefaultPerm if zero
+
+	// OnError is called with errors from compressing and removing backups,
+	// which happen in the background after a rotation. Nil ignores them.
+	OnError func(error)
+}
+
+// Writer appends to a file and rotates it according to its Options.
+// Rotated files are renamed to "<name>.<timestamp>", plus ".gz" when compressed.
+// It is safe for concurrent use; every Write lands in a single file.
+// A Write larger than MaxSize goes into a fresh file on its own.
+// If a new file cannot be opened after a rotation, the next Write tries again.
+type Writer struct {
+	filename string
+	opts     Options
+	now      func() time.Time
+
+	mu     sync.Mutex
+	file   *os.File // nil after Close or a failed rotation
+	size   int64
+	day    string // day of the last write
+	closed bool
+
+	millMu sync.Mutex     // serializes compression and cleanup of backups
+	mill   sync.WaitGroup // background compression and cleanup in progress
+}
+
+// New opens filename for appending, creating it if needed, and returns a Writer for it.
+func New(filename string, opts Options) (*Writer, error) {
+	if opts.Perm == 0 {
+		opts.Perm = fileutil.DefaultPerm
+	}
+	w := &Writer{filename: filename, opts: opts, now: time.Now}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write appends p to the current file, rotating first if p would exceed
+// MaxSize or a new day has started.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.reopen(); err != nil {
+		return 0, err
+	}
+	if w.shouldRotate(int64(len(p))) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	w.day = w.dayOf(w.now())
+	return n, err
+}
+
+// Rotate closes the current file, renames it to a backup and opens a new one.
+func (w *Writer) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.reopen(); err != nil {
+		return err
+	}
+	return w.rotate()
+}
+
+// Close closes the file and waits for pending compression and cleanup.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	var err error
+	w.closed = true
+	if w.file != nil {
+		err = w.file.Close()
+		w.file = nil
+	}
+	w.mu.Unlock()
+
+	w.mill.Wait()
+	return err
+}
+
+func (w *Writer) shouldRotate(n int64) bool {
+	if w.opts.MaxSize > 0 && w.size > 0 && w.size+n > w.opts.MaxSize {
+		return true
+	}
+	return w.opts.Daily && w.size > 0 && w.dayOf(w.now()) != w.day
+}
+
+func (w *Writer) dayOf(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// reopen opens the log file again if a previous rotation could not.
+func (w *Writer) reopen() error {
+	if w.closed {
+		return os.ErrClosed
+	}
+	if w.file == nil {
+		return w.open()
+	}
+	return nil
+}
+
+// open opens the log file and records its size and the day it was last written.
+func (w *Writer) open() error {
+	file, err := fileutil.OpenAppend(w.filename, w.opts.Perm)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	w.file, w.size, w.day = file, info.Size(), w.dayOf(w.now())
+	if info.Size() > 0 {
+		w.day = w.dayOf(info.ModTime())
+	}
+	return nil
+}
+
+// rotate closes the file, renames it to a backup and opens a new one.
+// On failure w.file is left nil, so the next call to reopen tries again.
+func (w *Writer) rotate() error {
+	err := w.file.Close()
+	w.file = nil
+	if err != nil {
+		return err
+	}
+
+	backup := w.backupName()
+	if err := os.Rename(w.filename, backup); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := w.open(); err != nil {
+		return err
+	}
+
+	w.mill.Add(1)
+	go func() {
+		defer w.mill.Done()
+		w.millMu.Lock()
+		defer w.millMu.Unlock()
+
+		if w.opts.Compress {
+			if err := compress(backup); err != nil {
+				w.fail(fmt.Errorf("rotate: compress %s: %w", backup, err))
+			}
+		}
+		if err := w.removeOldBackups(); err != nil {
+			w.fail(fmt.Errorf("rotate: remove old backups: %w", err))
+		}
+	}()
+	return nil
+}
+
+// fail reports an error of the background work to OnError, if set.
+func (w *Writer) fail(err error) {
+	if w.opts.OnError != nil {
+		w.opts.OnError(err)
+	}
+}
+
+// backupName returns an unused name for the next backup.
+func (w *Writer) backupName() string {
+	base := w.filename + "." + w.now().Format(backupTimeFormat)
+	name := base
+	for i := 1; exists(name) || exists(name+".gz"); i++ {
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+	return name
+}
+
+// backups returns the existing backups of the log file, oldest first.
+func (w *Writer) backups() ([]string, error) {
+	dir, base := filepath.Split(w.filename)
+	listDir := dir
+	if listDir == "" {
+		listDir = "."
+	}
+	entries, err := os.ReadDir(listDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []string
+	for _, e := range entries {
+		stamp, ok := strings.CutPrefix(e.Name(), base+".")
+		if !ok || len(stamp) < len(backupTimeFormat) {
+			continue
+		}
+		if _, err := time.Parse(backupTimeFormat, stamp[:len(backupTimeFormat)]); err == nil {
+			backups = append(backups, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
+
+func (w *Writer) removeOldBackups() error {
+	if w.opts.MaxBackups <= 0 {
+		return nil
+	}
+	backups, err := w.backups()
+	if err != nil {
+		return err
+	}
+	for len(backups) > w.opts.MaxBackups {
+		if err := os.Remove(backups[0]); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// compress gzips filename to filename.gz and removes the original.
+func compress(filename string) error {
+	src, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	err = fileutil.WriteAtomic(filename+".gz", info.Mode().Perm(), func(dst io.Writer) error {
+		zw := gzip.NewWriter(dst)
+		zw.Name = filepath.Base(filename)
+		zw.ModTime = info.ModTime()
+		if _, err := io.Copy(zw, src); err != nil {
+			return err
+		}
+		return zw.Close()
+	})
+	if err != nil {
+		return err
+	}
+
+	src.Close()
+	return os.Remove(filename)
+}
+
+func exists(name string) bool {
+	_, err := os.Lstat(name)
+	return err == nil
+}
@@ -0,0 +1,239 @@
+package rotate
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock returns a controllable time source advancing 1ms per call so that
+// every backup gets a distinct timestamp.
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(time.Millisecond)
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func newTestWriter(t *testing.T, opts Options) (*Writer, *fakeClock, string) {
+	t.Helper()
+	dir := t.TempDir()
+	clock := &fakeClock{t: time.Date(2025, 2, 9, 16, 0, 0, 0, time.Local)}
+	w, err := New(filepath.Join(dir, "output.txt"), opts)
+	if err != nil {
+		t.Fatalf("New returned error %v", err)
+	}
+	w.now = clock.now
+	return w, clock, dir
+}
+
+// listFiles returns the names in dir, sorted.
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func readFile(t *testing.T, filename string) string {
+	t.Helper()
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(filename, ".gz") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = zr
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestRotateBySize(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       Options
+		writes     []string
+		current    string
+		backups    []string
+		compressed bool
+	}{
+		{"no rotation needed", Options{MaxSize: 100}, []string{"aaaa\n", "bbbb\n"}, "aaaa\nbbbb\n", nil, false},
+		{"rotate on overflow", Options{MaxSize: 10}, []string{"aaaa\n", "bbbb\n", "cccc\n"}, "cccc\n", []string{"aaaa\nbbbb\n"}, false},
+		{"oversized write", Options{MaxSize: 4}, []string{"aaaa\n", "bbbb\n"}, "bbbb\n", []string{"aaaa\n"}, false},
+		{"keep backups", Options{MaxSize: 5, MaxBackups: 2}, []string{"1111\n", "2222\n", "3333\n", "4444\n"}, "4444\n", []string{"2222\n", "3333\n"}, false},
+		{"compress", Options{MaxSize: 5, Compress: true}, []string{"1111\n", "2222\n", "3333\n"}, "3333\n", []string{"1111\n", "2222\n"}, true},
+		{"compress and keep", Options{MaxSize: 5, MaxBackups: 1, Compress: true}, []string{"1111\n", "2222\n", "3333\n"}, "3333\n", []string{"2222\n"}, true},
+	}
+
+	for _, test := range tests {
+		w, _, dir := newTestWriter(t, test.opts)
+		for _, s := range test.writes {
+			if _, err := io.WriteString(w, s); err != nil {
+				t.Fatalf("%s: Write returned error %v", test.name, err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("%s: Close returned error %v", test.name, err)
+		}
+
+		if result := readFile(t, filepath.Join(dir, "output.txt")); result != test.current {
+			t.Errorf("%s: current file = %q; want %q", test.name, result, test.current)
+		}
+
+		backups, _ := w.backups()
+		if len(backups) != len(test.backups) {
+			t.Fatalf("%s: got backups %v; want %d", test.name, listFiles(t, dir), len(test.backups))
+		}
+		for i, b := range backups {
+			if strings.HasSuffix(b, ".gz") != test.compressed {
+				t.Errorf("%s: backup %s compressed = %v; want %v", test.name, b, !test.compressed, test.compressed)
+			}
+			if result := readFile(t, b); result != test.backups[i] {
+				t.Errorf("%s: backup %d = %q; want %q", test.name, i, result, test.backups[i])
+			}
+		}
+	}
+}
+
+func TestRotateDaily(t *testing.T) {
+	w, clock, dir := newTestWriter(t, Options{Daily: true})
+
+	io.WriteString(w, "monday\n")
+	io.WriteString(w, "still monday\n")
+	clock.advance(24 * time.Hour)
+	io.WriteString(w, "tuesday\n")
+	w.Close()
+
+	if result := readFile(t, filepath.Join(dir, "output.txt")); result != "tuesday\n" {
+		t.Errorf("current file = %q; want %q", result, "tuesday\n")
+	}
+	backups, _ := w.backups()
+	if len(backups) != 1 || readFile(t, backups[0]) != "monday\nstill monday\n" {
+		t.Errorf("backups = %v; want one with monday's lines", listFiles(t, dir))
+	}
+}
+
+// TestDailyEmptyFileWrittenLater opens an empty file through New and first
+// writes to it on a later day; that day's writes must stay in one file.
+func TestDailyEmptyFileWrittenLater(t *testing.T) {
+	dir := t.TempDir()
+	w, err := New(filepath.Join(dir, "output.txt"), Options{Daily: true})
+	if err != nil {
+		t.Fatalf("New returned error %v", err)
+	}
+	clock := &fakeClock{t: time.Now().Add(48 * time.Hour)}
+	w.now = clock.now
+
+	io.WriteString(w, "first\n")
+	io.WriteString(w, "second\n")
+	w.Close()
+
+	if result := readFile(t, filepath.Join(dir, "output.txt")); result != "first\nsecond\n" {
+		t.Errorf("current file = %q; want %q", result, "first\nsecond\n")
+	}
+	if backups, _ := w.backups(); len(backups) != 0 {
+		t.Errorf("backups = %v; want none", listFiles(t, dir))
+	}
+}
+
+func TestConcurrentWrites(t *testing.T) {
+	w, _, dir := newTestWriter(t, Options{MaxSize: 1000})
+
+	const goroutines, lines = 8, 200
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < lines; i++ {
+				fmt.Fprintf(w, "goroutine %d line %03d\n", g, i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	w.Close()
+
+	total := 0
+	for _, name := range listFiles(t, dir) {
+		content := readFile(t, filepath.Join(dir, name))
+		if len(content) > 1000 {
+			t.Errorf("%s has %d bytes; want at most 1000", name, len(content))
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+			if !strings.HasPrefix(line, "goroutine ") || len(line) != len("goroutine 0 line 000") {
+				t.Fatalf("%s has a torn line %q", name, line)
+			}
+			total++
+		}
+	}
+	if total != goroutines*lines {
+		t.Errorf("found %d lines; want %d", total, goroutines*lines)
+	}
+}
+
+func TestWriteAfterClose(t *testing.T) {
+	w, _, _ := newTestWriter(t, Options{})
+	w.Close()
+	if _, err := io.WriteString(w, "late\n"); err == nil {
+		t.Errorf("Write after Close returned no error")
+	}
+}
+
+func TestWriteAfterFailedRotate(t *testing.T) {
+	w, _, dir := newTestWriter(t, Options{})
+	io.WriteString(w, "before\n")
+
+	// Without its directory the new file cannot be opened.
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Rotate(); err == nil {
+		t.Fatal("Rotate without a directory returned no error")
+	}
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := io.WriteString(w, "after\n"); err != nil {
+		t.Fatalf("Write after a failed rotation returned error %v", err)
+	}
+	w.Close()
+	if result := readFile(t, filepath.Join(dir, "output.txt")); result != "after\n" {
+		t.Errorf("current file = %q; want %q", result, "after\n")
+	}
+}
@@ -0,0 +1,114 @@
+// Package search finds lines matching a keyword or regular expression in text,
+// like a small grep, and returns them as structured results.
+package search
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+)
+
+// MaxLineSize is the longest line Search accepts; longer lines make it fail
+// with bufio.ErrTooLong.
+const MaxLineSize = 16 * 1024 * 1024
+
+// SearchOptions controls what Search matches and how much context it keeps.
+type SearchOptions struct {
+	Keyword    string // text to look for
+	Regexp     bool   // treat Keyword as a regular expression
+	IgnoreCase bool   // match case-insensitively
+	WholeWord  bool   // only match Keyword as a whole word
+	Invert     bool   // select lines that do not match
+	Before     int    // context lines kept before each match
+	After      int    // context lines kept after each match
+}
+
+// Line is a numbered line of input.
+type Line struct {
+	Number int    // 1-based line number
+	Text   string // line content without the line break
+}
+
+// Span is a match inside a line as byte offsets [Start, End) into Line.Text.
+type Span struct {
+	Start, End int
+}
+
+// Match is a line selected by Search.
+// Context lines are attached to at most one match: a line that follows one
+// match and precedes the next belongs to the After of the first.
+type Match struct {
+	Line
+	Spans  []Span // every occurrence in the line; empty for inverted matches
+	Before []Line // context lines before the match
+	After  []Line // context lines after the match
+}
+
+// Compile turns the keyword and flags in opts into a regular expression.
+func Compile(opts SearchOptions) (*regexp.Regexp, error) {
+	pattern := opts.Keyword
+	if !opts.Regexp {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if opts.WholeWord {
+		pattern = `\b(?:` + pattern + `)\b`
+	}
+	if opts.IgnoreCase {
+		pattern = `(?i)` + pattern
+	}
+	return regexp.Compile(pattern)
+}
+
+// Search reads r line by line and returns every line selected by opts.
+func Search(r io.Reader, opts SearchOptions) ([]Match, error) {
+	re, err := Compile(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), MaxLineSize)
+
+	var matches []Match
+	var before []Line
+	afterLeft := 0
+
+	for number := 1; scanner.Scan(); number++ {
+		line := Line{Number: number, Text: scanner.Text()}
+		locs := re.FindAllStringIndex(line.Text, -1)
+
+		if (len(locs) > 0) != opts.Invert {
+			m := Match{Line: line, Before: before}
+			for _, loc := range locs {
+				m.Spans = append(m.Spans, Span{Start: loc[0], End: loc[1]})
+			}
+			matches = append(matches, m)
+			before = nil
+			afterLeft = opts.After
+		} else if afterLeft > 0 {
+			last := &matches[len(matches)-1]
+			last.After = append(last.After, line)
+			afterLeft--
+		} else if opts.Before > 0 {
+			before = append(before, line)
+			if len(before) > opts.Before {
+				before = before[1:]
+			}
+		}
+	}
+
+	return matches, scanner.Err()
+}
+
+// Count returns the number of occurrences in matches, or the number of
+// matched lines for an inverted search.
+func Count(matches []Match, opts SearchOptions) int {
+	if opts.Invert {
+		return len(matches)
+	}
+	n := 0
+	for _, m := range matches {
+		n += len(m.Spans)
+	}
+	return n
+}
@@ -0,0 +1,125 @@
+package search
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const sample = `alpha beta
+Beta gamma
+delta
+betamax beta
+epsilon
+zeta`
+
+// describe renders matches compactly as "line:start-end,...[before|after]".
+func describe(matches []Match) string {
+	var parts []string
+	for _, m := range matches {
+		s := fmt.Sprint(m.Number, ":")
+		for i, span := range m.Spans {
+			if i > 0 {
+				s += ","
+			}
+			s += fmt.Sprintf("%d-%d", span.Start, span.End)
+		}
+		if len(m.Before) > 0 || len(m.After) > 0 {
+			s += fmt.Sprintf("[%s|%s]", numbers(m.Before), numbers(m.After))
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+func numbers(lines []Line) string {
+	var s []string
+	for _, l := range lines {
+		s = append(s, fmt.Sprint(l.Number))
+	}
+	return strings.Join(s, ",")
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     SearchOptions
+		expected string
+	}{
+		{"literal", SearchOptions{Keyword: "beta"}, "1:6-10 4:0-4,8-12"},
+		{"no match", SearchOptions{Keyword: "omega"}, ""},
+		{"ignore case", SearchOptions{Keyword: "BETA", IgnoreCase: true}, "1:6-10 2:0-4 4:0-4,8-12"},
+		{"whole word", SearchOptions{Keyword: "beta", WholeWord: true}, "1:6-10 4:8-12"},
+		{"literal metacharacters", SearchOptions{Keyword: "a."}, ""},
+		{"regexp", SearchOptions{Keyword: `^[de]\w+$`, Regexp: true}, "3:0-5 5:0-7"},
+		{"invert", SearchOptions{Keyword: "a", Invert: true}, "5:"},
+		{"invert context", SearchOptions{Keyword: "et", Invert: true, After: 1}, "3:[|4] 5:[|6]"},
+		{"after context", SearchOptions{Keyword: "delta", After: 2}, "3:0-5[|4,5]"},
+		{"before context", SearchOptions{Keyword: "zeta", Before: 2}, "6:0-4[4,5|]"},
+		{"context at edges", SearchOptions{Keyword: "alpha", Before: 3, After: 1}, "1:0-5[|2]"},
+		{"shared context", SearchOptions{Keyword: "delta|zeta", Regexp: true, Before: 1, After: 1}, "3:0-5[2|4] 6:0-4[5|]"},
+		{"adjacent matches", SearchOptions{Keyword: "beta", IgnoreCase: true, Before: 1, After: 1}, "1:6-10 2:0-4[|3] 4:0-4,8-12[|5]"},
+	}
+
+	for _, test := range tests {
+		matches, err := Search(strings.NewReader(sample), test.opts)
+		if err != nil {
+			t.Errorf("%s: Search returned error %v", test.name, err)
+			continue
+		}
+		if result := describe(matches); result != test.expected {
+			t.Errorf("%s: Search(%+v) = %q; want %q", test.name, test.opts, result, test.expected)
+		}
+	}
+}
+
+func TestSearchLineText(t *testing.T) {
+	matches, err := Search(strings.NewReader("one\r\ntwo three\nthree"), SearchOptions{Keyword: "three"})
+	if err != nil {
+		t.Fatalf("Search returned error %v", err)
+	}
+
+	expected := []Line{{2, "two three"}, {3, "three"}}
+	if len(matches) != len(expected) {
+		t.Fatalf("got %d matches; want %d", len(matches), len(expected))
+	}
+	for i, m := range matches {
+		if m.Line != expected[i] {
+			t.Errorf("match %d = %+v; want %+v", i, m.Line, expected[i])
+		}
+	}
+}
+
+func TestSearchErrors(t *testing.T) {
+	if _, err := Search(strings.NewReader(sample), SearchOptions{Keyword: "(", Regexp: true}); err == nil {
+		t.Errorf("Search with invalid regexp returned no error")
+	}
+
+	long := strings.Repeat("x", MaxLineSize+1)
+	if _, err := Search(strings.NewReader(long), SearchOptions{Keyword: "x"}); !errors.Is(err, bufio.ErrTooLong) {
+		t.Errorf("Search with an over-long line returned %v; want %v", err, bufio.ErrTooLong)
+	}
+}
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		opts     SearchOptions
+		expected int
+	}{
+		{SearchOptions{Keyword: "beta"}, 3},
+		{SearchOptions{Keyword: "beta", IgnoreCase: true}, 4},
+		{SearchOptions{Keyword: "et", Invert: true}, 2},
+	}
+
+	for _, test := range tests {
+		matches, err := Search(strings.NewReader(sample), test.opts)
+		if err != nil {
+			t.Fatalf("Search returned error %v", err)
+		}
+		if result := Count(matches, test.opts); result != test.expected {
+			t.Errorf("Count(%+v) = %d; want %d", test.opts, result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Package tail prints the end of a file and optionally follows data appended
+// to it, like `tail -n N -f`, coping with truncation and log rotation.
+package tail
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+)
+
+// DefaultPollInterval is how often a followed file is checked for changes.
+const DefaultPollInterval = 250 * time.Millisecond
+
+// chunkSize is how many bytes are read per step when scanning backwards.
+var chunkSize int64 = 4096
+
+// Options controls Tail.
+type Options struct {
+	Lines        int           // print only the last Lines lines; 0 or less prints the whole file
+	Follow       bool          // keep printing data appended to the file
+	PollInterval time.Duration // how often to check a followed file; DefaultPollInterval if zero
+}
+
+// Tail writes the last opts.Lines lines of filename to w. With opts.Follow it
+// then keeps writing appended data until ctx is cancelled, and returns ctx.Err().
+//
+// A file that shrinks is assumed to be truncated and is read again from the
+// start. A file that is replaced, for example renamed away by log rotation and
+// recreated, is read to its end before the new file is followed from the start.
+func Tail(ctx context.Context, filename string, w io.Writer, opts Options) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer func() { file.Close() }() // file changes on rotation
+
+	offset := int64(0)
+	if opts.Lines > 0 {
+		if offset, err = LastLinesOffset(file, opts.Lines); err != nil {
+			return err
+		}
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	n, err := io.Copy(w, file)
+	if err != nil || !opts.Follow {
+		return err
+	}
+	offset += n
+
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		current, err := file.Stat()
+		if err != nil {
+			return err
+		}
+
+		// Rotated: finish the old file, then switch to the new one.
+		// A missing path means rotation is still in progress, so keep waiting.
+		if info, err := os.Stat(filename); err == nil && !os.SameFile(info, current) {
+			if _, err := io.Copy(w, file); err != nil {
+				return err
+			}
+			next, err := os.Open(filename)
+			if err != nil {
+				continue
+			}
+			file.Close()
+			file, offset = next, 0
+		} else if current.Size() < offset {
+			// Truncated: start over from the beginning.
+			if offset, err = file.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+		}
+
+		n, err := io.Copy(w, file)
+		if err != nil {
+			return err
+		}
+		offset += n
+	}
+}
+
+// LastLinesOffset returns the offset in r where its last n lines begin,
+// scanning backwards from the end so that only the tail of r is read.
+// A newline at the very end of r does not start an extra empty line.
+func LastLinesOffset(r io.ReadSeeker, n int) (int64, error) {
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, chunkSize)
+	pos := end
+	newlines := 0
+	for pos > 0 {
+		size := min(chunkSize, pos)
+		pos -= size
+		if _, err := r.Seek(pos, io.SeekStart); err != nil {
+			return 0, err
+		}
+		chunk := buf[:size]
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return 0, err
+		}
+
+		for i := len(chunk) - 1; i >= 0; i-- {
+			if chunk[i] != '\n' || pos+int64(i) == end-1 {
+				continue
+			}
+			newlines++
+			if newlines == n {
+				return pos + int64(i) + 1, nil
+			}
+		}
+	}
+	return 0, nil
+}
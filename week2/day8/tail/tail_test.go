@@ -0,0 +1,155 @@
+package tail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLastLinesOffset(t *testing.T) {
+	defer func(size int64) { chunkSize = size }(chunkSize)
+
+	tests := []struct {
+		content  string
+		n        int
+		expected string
+	}{
+		{"", 3, ""},
+		{"one\n", 1, "one\n"},
+		{"one\ntwo\nthree\n", 1, "three\n"},
+		{"one\ntwo\nthree\n", 2, "two\nthree\n"},
+		{"one\ntwo\nthree\n", 5, "one\ntwo\nthree\n"},
+		{"one\ntwo\nthree", 1, "three"},
+		{"one\ntwo\nthree", 2, "two\nthree"},
+		{"one\r\ntwo\r\n", 1, "two\r\n"},
+		{"one\n\n\n", 2, "\n\n"},
+		{"\n", 1, "\n"},
+	}
+
+	// Tiny chunks exercise lines that span several backward reads.
+	for _, size := range []int64{1, 2, 3, 4096} {
+		chunkSize = size
+		for _, test := range tests {
+			r := strings.NewReader(test.content)
+			offset, err := LastLinesOffset(r, test.n)
+			if err != nil {
+				t.Fatalf("LastLinesOffset(%q, %d) returned error %v", test.content, test.n, err)
+			}
+			if result := test.content[offset:]; result != test.expected {
+				t.Errorf("chunk %d: LastLinesOffset(%q, %d) selects %q; want %q", size, test.content, test.n, result, test.expected)
+			}
+		}
+	}
+}
+
+func TestTail(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "sample.txt")
+	if err := os.WriteFile(filename, []byte("1\n2\n3\n4\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := Tail(context.Background(), filename, &out, Options{Lines: 2}); err != nil {
+		t.Fatalf("Tail returned error %v", err)
+	}
+	if out.String() != "3\n4\n" {
+		t.Errorf("Tail printed %q; want %q", out.String(), "3\n4\n")
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for one writer and one reader goroutine.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func waitFor(t *testing.T, out *syncBuffer, expected string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for out.String() != expected {
+		if time.Now().After(deadline) {
+			t.Fatalf("output = %q; want %q", out.String(), expected)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func appendString(t *testing.T, filename, s string) {
+	t.Helper()
+	f, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(s); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTailFollow(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "app.log")
+	appendString(t, filename, "old 1\nold 2\nold 3\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var out syncBuffer
+	done := make(chan error, 1)
+	go func() {
+		done <- Tail(ctx, filename, &out, Options{Lines: 1, Follow: true, PollInterval: 5 * time.Millisecond})
+	}()
+
+	expected := "old 3\n"
+	waitFor(t, &out, expected)
+
+	// Appended data is followed.
+	appendString(t, filename, "new 1\n")
+	expected += "new 1\n"
+	waitFor(t, &out, expected)
+
+	// Truncation restarts from the beginning.
+	if err := os.Truncate(filename, 0); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	appendString(t, filename, "after truncate\n")
+	expected += "after truncate\n"
+	waitFor(t, &out, expected)
+
+	// Rotation: the old file is renamed away and a new one takes its place.
+	// Data written to the old file just before the switch is not lost.
+	appendString(t, filename, "last in old\n")
+	if err := os.Rename(filename, filename+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendString(t, filename, "first in new\n")
+	expected += "last in old\nfirst in new\n"
+	waitFor(t, &out, expected)
+
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Tail returned %v; want %v", err, context.Canceled)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Tail did not return after cancel")
+	}
+}
@@ -0,0 +1,103 @@
+// Package walk expands the files and directories given on a command line into
+// the regular files below them, filtered by glob patterns on their base names.
+package walk
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotRegular is reported for a root that is neither a regular file nor a
+// directory, such as a device or a named pipe.
+var ErrNotRegular = errors.New("not a regular file or directory")
+
+// Patterns is a list of filepath.Match globs. It implements flag.Value, so a
+// command can accept a repeatable flag with flag.Var(&patterns, ...).
+type Patterns []string
+
+func (p *Patterns) String() string {
+	return strings.Join(*p, ",")
+}
+
+// Set adds pattern, rejecting malformed globs.
+func (p *Patterns) Set(pattern string) error {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return err
+	}
+	*p = append(*p, pattern)
+	return nil
+}
+
+// MatchAny reports whether the base name of path matches any of the patterns.
+func (p Patterns) MatchAny(path string) bool {
+	name := filepath.Base(path)
+	for _, pattern := range p {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Files walks every root and returns the regular files below it, in lexical
+// order per root. Excluded directories are skipped entirely; include patterns
+// only apply to files. A root given explicitly is never filtered out, and a
+// root that is a symlink is followed; symlinks below the roots are skipped.
+// Paths that cannot be read and roots that are neither regular files nor
+// directories are reported in errs and the walk goes on.
+func Files(roots []string, include, exclude Patterns) (files []string, errs []error) {
+	for _, root := range roots {
+		info, err := os.Stat(root)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if info.Mode().IsRegular() {
+			files = append(files, root)
+			continue
+		}
+		if !info.IsDir() {
+			errs = append(errs, fmt.Errorf("%s: %w", root, ErrNotRegular))
+			continue
+		}
+
+		// WalkDir does not follow a symlinked root, so walk its target and
+		// report the paths below root.
+		target, err := filepath.EvalSymlinks(root)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		filepath.WalkDir(target, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				errs = append(errs, err)
+				return nil
+			}
+			if path == target {
+				return nil
+			}
+			if rel, err := filepath.Rel(target, path); err == nil {
+				path = filepath.Join(root, rel)
+			}
+			if exclude.MatchAny(path) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			if len(include) > 0 && !include.MatchAny(path) {
+				return nil
+			}
+			files = append(files, path)
+			return nil
+		})
+	}
+	return files, errs
+}
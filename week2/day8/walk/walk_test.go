@@ -0,0 +1,121 @@
+package walk
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestPatternsFlag(t *testing.T) {
+	var p Patterns
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&p, "include", "")
+
+	if err := fs.Parse([]string{"-include", "*.go", "-include", "*.md"}); err != nil {
+		t.Fatal(err)
+	}
+	if p.String() != "*.go,*.md" {
+		t.Errorf("Patterns = %q; want %q", p.String(), "*.go,*.md")
+	}
+	if err := fs.Parse([]string{"-include", "[a-"}); err == nil {
+		t.Error("Set of a malformed glob succeeded; want an error")
+	}
+}
+
+func TestMatchAny(t *testing.T) {
+	p := Patterns{"*.go", "README*"}
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"main.go", true},
+		{filepath.Join("a", "b", "c.go"), true},
+		{filepath.Join("docs", "README.md"), true},
+		{"main.go.bak", false},
+		{filepath.Join("go", "file.txt"), false}, // only the base name is matched
+	}
+
+	for _, test := range tests {
+		if result := p.MatchAny(test.path); result != test.expected {
+			t.Errorf("MatchAny(%q) = %v; want %v", test.path, result, test.expected)
+		}
+	}
+	if (Patterns{}).MatchAny("main.go") {
+		t.Error("empty Patterns matched; want no match")
+	}
+}
+
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.go", "b.txt", "sub/c.go", "sub/d.txt", "vendor/e.go", "sub/vendor/f.go"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	explicit := filepath.Join(dir, "b.txt")
+	linkFile, linkDir := filepath.Join(dir, "link.go"), filepath.Join(dir, "linkdir")
+	symlinkErr := os.Symlink("a.go", linkFile)
+	if symlinkErr == nil {
+		symlinkErr = os.Symlink("sub", linkDir)
+	}
+
+	tests := []struct {
+		roots            []string
+		include, exclude Patterns
+		expected         []string
+	}{
+		{[]string{dir}, nil, nil, []string{"a.go", "b.txt", "sub/c.go", "sub/d.txt", "sub/vendor/f.go", "vendor/e.go"}},
+		{[]string{dir}, Patterns{"*.go"}, nil, []string{"a.go", "sub/c.go", "sub/vendor/f.go", "vendor/e.go"}},
+		{[]string{dir}, Patterns{"*.go"}, Patterns{"vendor"}, []string{"a.go", "sub/c.go"}},
+		{[]string{dir}, nil, Patterns{"*.txt", "sub"}, []string{"a.go", "vendor/e.go"}},
+		{[]string{explicit}, Patterns{"*.go"}, Patterns{"*.txt"}, []string{"b.txt"}}, // roots are never filtered
+		// Symlinked roots are followed, symlinks below a root are not.
+		{[]string{linkFile, linkDir}, nil, Patterns{"vendor"}, []string{"link.go", "linkdir/c.go", "linkdir/d.txt"}},
+	}
+
+	for _, test := range tests {
+		if symlinkErr != nil && test.roots[0] == linkFile {
+			t.Logf("skipping symlinked roots: %v", symlinkErr)
+			continue
+		}
+		files, errs := Files(test.roots, test.include, test.exclude)
+		if len(errs) > 0 {
+			t.Errorf("Files(%v, %v, %v) errors %v", test.roots, test.include, test.exclude, errs)
+		}
+		got := make([]string, len(files))
+		for i, f := range files {
+			rel, _ := filepath.Rel(dir, f)
+			got[i] = filepath.ToSlash(rel)
+		}
+		if strings.Join(got, " ") != strings.Join(test.expected, " ") {
+			t.Errorf("Files(include %v, exclude %v) = %v; want %v", test.include, test.exclude, got, test.expected)
+		}
+	}
+}
+
+func TestFilesMissingRoot(t *testing.T) {
+	files, errs := Files([]string{filepath.Join(t.TempDir(), "missing")}, nil, nil)
+	if len(files) != 0 || len(errs) != 1 || !os.IsNotExist(errs[0]) {
+		t.Errorf("Files(missing) = %v, %v; want no files and one not-exist error", files, errs)
+	}
+}
+
+func TestFilesNotRegular(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no device file to test with")
+	}
+	files, errs := Files([]string{os.DevNull}, nil, nil)
+	if len(files) != 0 || len(errs) != 1 || !errors.Is(errs[0], ErrNotRegular) {
+		t.Errorf("Files(%s) = %v, %v; want no files and ErrNotRegular", os.DevNull, files, errs)
+	}
+}
@@ -0,0 +1,152 @@
+// Package wc counts lines, words and bytes of a stream, like the wc command.
+// It never buffers whole lines, so arbitrarily long lines are fine, and it
+// detects binary files by sniffing for a NUL byte.
+package wc
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Counts holds the statistics of a single stream.
+type Counts struct {
+	Lines, Words, Bytes int
+}
+
+// Add adds o to c.
+func (c *Counts) Add(o Counts) {
+	c.Lines += o.Lines
+	c.Words += o.Words
+	c.Bytes += o.Bytes
+}
+
+// BinarySniffLen is how many leading bytes are inspected for a NUL byte
+// when deciding whether a stream is binary (the same heuristic git uses).
+const BinarySniffLen = 8000
+
+// ErrBinary is returned by Count and CountFile for binary input.
+var ErrBinary = errors.New("binary file")
+
+// Counter counts lines, words and bytes of a stream written into it.
+//
+// A line is any run of bytes ended by '\n'; a final line without a trailing
+// newline is counted too. "\r\n" counts as a single line break because '\r'
+// is treated as whitespace. Words are separated by Unicode whitespace, and
+// runes split across two Writes are decoded as one.
+type Counter struct {
+	counts  Counts
+	inWord  bool
+	last    byte
+	partial []byte // incomplete UTF-8 sequence left over from the previous Write
+}
+
+func (c *Counter) Write(p []byte) (int, error) {
+	n := len(p)
+	if n == 0 {
+		return 0, nil
+	}
+	c.counts.Bytes += n
+	c.last = p[n-1]
+
+	// Finish a rune that was split across two writes.
+	if len(c.partial) > 0 {
+		for len(p) > 0 && !utf8.FullRune(c.partial) {
+			c.partial = append(c.partial, p[0])
+			p = p[1:]
+		}
+		if !utf8.FullRune(c.partial) {
+			return n, nil
+		}
+		r, size := utf8.DecodeRune(c.partial)
+		c.step(false, unicode.IsSpace(r))
+		p = append(c.partial[size:], p...)
+		c.partial = nil
+	}
+
+	for i := 0; i < len(p); {
+		b := p[i]
+		if b < utf8.RuneSelf {
+			c.step(b == '\n', b == ' ' || (b >= '\t' && b <= '\r'))
+			i++
+			continue
+		}
+		if !utf8.FullRune(p[i:]) {
+			c.partial = append(c.partial, p[i:]...)
+			break
+		}
+		r, size := utf8.DecodeRune(p[i:])
+		c.step(false, unicode.IsSpace(r))
+		i += size
+	}
+	return n, nil
+}
+
+func (c *Counter) step(newline, space bool) {
+	if newline {
+		c.counts.Lines++
+	}
+	if space {
+		c.inWord = false
+	} else if !c.inWord {
+		c.inWord = true
+		c.counts.Words++
+	}
+}
+
+// Counts returns the counts so far, accounting for a missing trailing newline
+// and an incomplete rune at the end.
+func (c *Counter) Counts() Counts {
+	counts := c.counts
+	if counts.Bytes > 0 && c.last != '\n' {
+		counts.Lines++
+	}
+	if len(c.partial) > 0 && !c.inWord {
+		counts.Words++
+	}
+	return counts
+}
+
+// Count counts lines, words and bytes of r.
+// Input with a NUL byte in the first BinarySniffLen bytes is binary: unless
+// countBinary is set, Count stops there and returns ErrBinary.
+func Count(r io.Reader, countBinary bool) (Counts, error) {
+	reader := bufio.NewReaderSize(r, 64*1024)
+	head, err := reader.Peek(BinarySniffLen)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return Counts{}, err
+	}
+	if !countBinary && bytes.IndexByte(head, 0) >= 0 {
+		return Counts{}, ErrBinary
+	}
+
+	var c Counter
+	if _, err := io.Copy(&c, reader); err != nil {
+		return Counts{}, err
+	}
+	return c.Counts(), nil
+}
+
+// CountFile is Count for a file. For a binary file it returns ErrBinary with
+// only Bytes filled in, taken from the file size.
+func CountFile(filename string, countBinary bool) (Counts, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return Counts{}, err
+	}
+	defer file.Close()
+
+	counts, err := Count(file, countBinary)
+	if errors.Is(err, ErrBinary) {
+		info, statErr := file.Stat()
+		if statErr != nil {
+			return Counts{}, statErr
+		}
+		return Counts{Bytes: int(info.Size())}, err
+	}
+	return counts, err
+}
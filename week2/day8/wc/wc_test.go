@@ -0,0 +1,119 @@
+package wc
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Counts
+	}{
+		{"empty", "", Counts{0, 0, 0}},
+		{"one line", "hello world\n", Counts{1, 2, 12}},
+		{"no final newline", "hello\nworld", Counts{2, 2, 11}},
+		{"only newlines", "\n\n\n", Counts{3, 0, 3}},
+		{"CRLF", "a b\r\nc\r\n", Counts{2, 3, 8}},
+		{"CRLF without final newline", "a\r\nb", Counts{2, 2, 4}},
+		{"tabs and spaces", "  a\t\tb  \n", Counts{1, 2, 9}},
+		{"unicode spaces", "a\u00a0b\u3000c\n", Counts{1, 3, 9}},
+		{"multibyte words", "héllo wörld\n", Counts{1, 2, 14}},
+		{"long line", strings.Repeat("x", 200000) + "\n", Counts{1, 1, 200001}},
+	}
+
+	for _, test := range tests {
+		result, err := Count(strings.NewReader(test.input), false)
+		if err != nil || result != test.expected {
+			t.Errorf("%s: Count = %+v, %v; want %+v", test.name, result, err, test.expected)
+		}
+	}
+}
+
+// TestCounterSplitRunes writes input one chunk at a time, cutting it at every
+// possible position, so multibyte runes get split across Writes.
+func TestCounterSplitRunes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Counts
+	}{
+		{"日本 語\n", Counts{1, 2, 11}},
+		{"a\u3000b", Counts{1, 2, 5}},     // the ideographic space is 3 bytes
+		{"x\u2028y\r\n", Counts{1, 2, 7}}, // U+2028 LINE SEPARATOR is space, not a line break
+	}
+
+	for _, test := range tests {
+		for cut := 0; cut <= len(test.input); cut++ {
+			var c Counter
+			c.Write([]byte(test.input[:cut]))
+			c.Write([]byte(test.input[cut:]))
+			if result := c.Counts(); result != test.expected {
+				t.Errorf("Counter(%q cut at %d) = %+v; want %+v", test.input, cut, result, test.expected)
+			}
+		}
+
+		var c Counter
+		for i := 0; i < len(test.input); i++ {
+			c.Write([]byte{test.input[i]})
+		}
+		if result := c.Counts(); result != test.expected {
+			t.Errorf("Counter(%q byte by byte) = %+v; want %+v", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestCounterTruncatedRune(t *testing.T) {
+	var c Counter
+	c.Write([]byte("ab \xe6\x97")) // the last rune is cut off
+	if result, want := c.Counts(), (Counts{1, 2, 5}); result != want {
+		t.Errorf("Counts = %+v; want %+v", result, want)
+	}
+}
+
+func TestCountBinary(t *testing.T) {
+	input := []byte("text\x00more\nlines\n")
+
+	if _, err := Count(bytes.NewReader(input), false); !errors.Is(err, ErrBinary) {
+		t.Errorf("Count(binary) error %v; want ErrBinary", err)
+	}
+	if result, err := Count(bytes.NewReader(input), true); err != nil || result != (Counts{2, 2, 16}) {
+		t.Errorf("Count(binary, countBinary) = %+v, %v; want {2 2 16}", result, err)
+	}
+
+	// A NUL byte after the sniffed prefix is not detected.
+	late := append(bytes.Repeat([]byte("a"), BinarySniffLen), 0)
+	if _, err := Count(bytes.NewReader(late), false); err != nil {
+		t.Errorf("Count(late NUL) error %v; want nil", err)
+	}
+}
+
+func TestCountFile(t *testing.T) {
+	dir := t.TempDir()
+	text := filepath.Join(dir, "text.txt")
+	binary := filepath.Join(dir, "binary.bin")
+	os.WriteFile(text, []byte("one\ntwo three\n"), 0644)
+	os.WriteFile(binary, []byte{1, 0, 2, 3}, 0644)
+
+	if result, err := CountFile(text, false); err != nil || result != (Counts{2, 3, 14}) {
+		t.Errorf("CountFile(text) = %+v, %v; want {2 3 14}", result, err)
+	}
+	if result, err := CountFile(binary, false); !errors.Is(err, ErrBinary) || result != (Counts{Bytes: 4}) {
+		t.Errorf("CountFile(binary) = %+v, %v; want {0 0 4}, ErrBinary", result, err)
+	}
+	if _, err := CountFile(filepath.Join(dir, "missing"), false); !os.IsNotExist(err) {
+		t.Errorf("CountFile(missing) error %v; want not exist", err)
+	}
+}
+
+func TestCountsAdd(t *testing.T) {
+	total := Counts{1, 2, 3}
+	total.Add(Counts{10, 20, 30})
+	if total != (Counts{11, 22, 33}) {
+		t.Errorf("Add = %+v; want {11 22 33}", total)
+	}
+}
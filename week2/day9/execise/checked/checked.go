@@ -0,0 +1,101 @@
+// Package checked provides integer arithmetic for every integer type that
+// reports overflow and division by zero as errors instead of wrapping or panicking.
+package checked
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Signed is the set of signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is the set of unsigned integer types.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is the set of all integer types.
+type Integer interface {
+	Signed | Unsigned
+}
+
+var (
+	ErrOverflow     = errors.New("integer overflow")
+	ErrDivideByZero = errors.New("cannot divide by zero")
+)
+
+// Add returns a + b, or an error wrapping ErrOverflow.
+func Add[T Integer](a, b T) (T, error) {
+	c := a + b
+	if (b > 0 && c < a) || (b < 0 && c > a) {
+		return 0, overflow("+", a, b)
+	}
+	return c, nil
+}
+
+// Sub returns a - b, or an error wrapping ErrOverflow.
+func Sub[T Integer](a, b T) (T, error) {
+	c := a - b
+	if (b > 0 && c > a) || (b < 0 && c < a) {
+		return 0, overflow("-", a, b)
+	}
+	return c, nil
+}
+
+// Mul returns a * b, or an error wrapping ErrOverflow.
+func Mul[T Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	if (isMinusOne(a) && isMin(b)) || (isMinusOne(b) && isMin(a)) {
+		return 0, overflow("*", a, b)
+	}
+	c := a * b
+	if c/b != a {
+		return 0, overflow("*", a, b)
+	}
+	return c, nil
+}
+
+// Div returns a / b truncated toward zero, or an error wrapping
+// ErrDivideByZero or ErrOverflow (the most negative value divided by -1).
+func Div[T Integer](a, b T) (T, error) {
+	if b == 0 {
+		return 0, divideByZero("/", a)
+	}
+	if isMinusOne(b) && isMin(a) {
+		return 0, overflow("/", a, b)
+	}
+	return a / b, nil
+}
+
+// Mod returns the remainder a % b, which has the sign of a, or an error
+// wrapping ErrDivideByZero.
+func Mod[T Integer](a, b T) (T, error) {
+	if b == 0 {
+		return 0, divideByZero("%", a)
+	}
+	return a % b, nil
+}
+
+// isMinusOne reports whether x is -1; it is always false for unsigned types.
+func isMinusOne[T Integer](x T) bool {
+	return x < 0 && x == ^T(0)
+}
+
+// isMin reports whether x is the most negative value of a signed type,
+// the only negative value that is its own negation.
+func isMin[T Integer](x T) bool {
+	return x < 0 && -x == x
+}
+
+func overflow[T Integer](op string, a, b T) error {
+	return fmt.Errorf("%v %s %v: %w", a, op, b, ErrOverflow)
+}
+
+func divideByZero[T Integer](op string, a T) error {
+	return fmt.Errorf("%v %s 0: %w", a, op, ErrDivideByZero)
+}
@@ -0,0 +1,131 @@
+package checked
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// exact applies op to a and b as int64 values, which cannot overflow for 8-bit inputs.
+func exact(op string, a, b int64) int64 {
+	switch op {
+	case "+":
+		return a + b
+	case "-":
+		return a - b
+	case "*":
+		return a * b
+	case "/":
+		return a / b
+	}
+	return a % b
+}
+
+func check[T Integer](t *testing.T, op string, fn func(a, b T) (T, error), a, b T, lo, hi int64) {
+	t.Helper()
+	result, err := fn(a, b)
+
+	if b == 0 && (op == "/" || op == "%") {
+		if !errors.Is(err, ErrDivideByZero) {
+			t.Fatalf("%v %s %v: got %v, %v; want ErrDivideByZero", a, op, b, result, err)
+		}
+		return
+	}
+
+	want := exact(op, int64(a), int64(b))
+	if want < lo || want > hi {
+		if !errors.Is(err, ErrOverflow) {
+			t.Fatalf("%v %s %v: got %v, %v; want ErrOverflow", a, op, b, result, err)
+		}
+		return
+	}
+	if err != nil || int64(result) != want {
+		t.Fatalf("%v %s %v: got %v, %v; want %d", a, op, b, result, err, want)
+	}
+}
+
+func TestExhaustiveInt8(t *testing.T) {
+	ops := map[string]func(a, b int8) (int8, error){
+		"+": Add[int8], "-": Sub[int8], "*": Mul[int8], "/": Div[int8], "%": Mod[int8],
+	}
+	for op, fn := range ops {
+		for a := math.MinInt8; a <= math.MaxInt8; a++ {
+			for b := math.MinInt8; b <= math.MaxInt8; b++ {
+				check(t, op, fn, int8(a), int8(b), math.MinInt8, math.MaxInt8)
+			}
+		}
+	}
+}
+
+func TestExhaustiveUint8(t *testing.T) {
+	ops := map[string]func(a, b uint8) (uint8, error){
+		"+": Add[uint8], "-": Sub[uint8], "*": Mul[uint8], "/": Div[uint8], "%": Mod[uint8],
+	}
+	for op, fn := range ops {
+		for a := 0; a <= math.MaxUint8; a++ {
+			for b := 0; b <= math.MaxUint8; b++ {
+				check(t, op, fn, uint8(a), uint8(b), 0, math.MaxUint8)
+			}
+		}
+	}
+}
+
+func TestInt64(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func(a, b int64) (int64, error)
+		a, b     int64
+		expected int64
+		err      error
+	}{
+		{"Add", Add[int64], 10, 5, 15, nil},
+		{"Add", Add[int64], math.MaxInt64, 1, 0, ErrOverflow},
+		{"Add", Add[int64], math.MinInt64, -1, 0, ErrOverflow},
+		{"Add", Add[int64], math.MaxInt64, math.MinInt64, -1, nil},
+		{"Sub", Sub[int64], 10, 5, 5, nil},
+		{"Sub", Sub[int64], math.MinInt64, 1, 0, ErrOverflow},
+		{"Sub", Sub[int64], 0, math.MinInt64, 0, ErrOverflow},
+		{"Sub", Sub[int64], -1, math.MinInt64, math.MaxInt64, nil},
+		{"Mul", Mul[int64], 10, 5, 50, nil},
+		{"Mul", Mul[int64], math.MaxInt64, 2, 0, ErrOverflow},
+		{"Mul", Mul[int64], math.MinInt64, -1, 0, ErrOverflow},
+		{"Mul", Mul[int64], -1, math.MinInt64, 0, ErrOverflow},
+		{"Mul", Mul[int64], 1 << 32, 1 << 31, 0, ErrOverflow},
+		{"Mul", Mul[int64], -(1 << 31), 1 << 32, math.MinInt64, nil},
+		{"Div", Div[int64], 10, 5, 2, nil},
+		{"Div", Div[int64], -7, 2, -3, nil},
+		{"Div", Div[int64], 10, 0, 0, ErrDivideByZero},
+		{"Div", Div[int64], math.MinInt64, -1, 0, ErrOverflow},
+		{"Mod", Mod[int64], -7, 2, -1, nil},
+		{"Mod", Mod[int64], 7, 0, 0, ErrDivideByZero},
+		{"Mod", Mod[int64], math.MinInt64, -1, 0, nil},
+	}
+
+	for _, test := range tests {
+		result, err := test.fn(test.a, test.b)
+		if result != test.expected || !errors.Is(err, test.err) {
+			t.Errorf("%s(%d, %d) = %d, %v; want %d, %v", test.name, test.a, test.b, result, err, test.expected, test.err)
+		}
+	}
+}
+
+func TestUint64(t *testing.T) {
+	if _, err := Add[uint64](math.MaxUint64, 1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Add(MaxUint64, 1) error = %v; want ErrOverflow", err)
+	}
+	if _, err := Sub[uint64](0, 1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Sub(0, 1) error = %v; want ErrOverflow", err)
+	}
+	if result, err := Mul[uint64](1<<32, 1<<31); result != 1<<63 || err != nil {
+		t.Errorf("Mul(1<<32, 1<<31) = %d, %v; want %d, nil", result, err, uint64(1<<63))
+	}
+	if result, err := Div[uint64](math.MaxUint64, math.MaxUint64); result != 1 || err != nil {
+		t.Errorf("Div(MaxUint64, MaxUint64) = %d, %v; want 1, nil", result, err)
+	}
+}
+
+func BenchmarkAdd(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Add(10, 20)
+	}
+}
@@ -26,4 +26,13 @@ func main() {
 		fmt.Println("Quotient of 10 / 0:", quotient)
 	}
 
+	price := mathutils.MustParseDecimal("19.99")
+	total := price.Mul(mathutils.NewDecimal(3, 0))
+	share, err := total.Div(mathutils.NewDecimal(7, 0), 2, mathutils.HalfEven)
+	if err != nil {
+		fmt.Println("Error:", err)
+	} else {
+		fmt.Println("Decimal 19.99 * 3:", total)
+		fmt.Println("Decimal 59.97 / 7:", share)
+	}
 }
@@ -0,0 +1,259 @@
+package mathutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidDecimal is returned when a string is not a decimal number.
+var ErrInvalidDecimal = errors.New("invalid decimal")
+
+// maxExponent bounds the exponent ParseDecimal accepts, so that input such as
+// "1e999999999" cannot make it allocate a huge coefficient.
+const maxExponent = 10000
+
+// Decimal is an exact fixed-point decimal number: coef * 10^-scale.
+// Unlike int or float64 it can represent amounts of money such as 0.10
+// exactly and never overflows. The zero value is 0.
+// Decimals are immutable; every operation returns a new value.
+type Decimal struct {
+	coef  *big.Int // nil means 0
+	scale int      // number of digits after the decimal point, never negative
+}
+
+// NewDecimal returns coef * 10^-scale, so NewDecimal(1999, 2) is 19.99 and
+// NewDecimal(15, -1) is 150.
+func NewDecimal(coef int64, scale int) Decimal {
+	d := Decimal{coef: big.NewInt(coef), scale: scale}
+	if scale < 0 {
+		d = Decimal{coef: d.coef.Mul(d.coef, pow10(-scale))}
+	}
+	return d
+}
+
+// ParseDecimal parses a string such as "-12.340" or "1.5e-3". The scale of
+// the result is the number of digits after the point, so trailing zeros are
+// kept, less the exponent if there is one: "1.50e1" is 15.0 and "1e3" is 1000.
+// Exponents beyond ±10000 are rejected.
+func ParseDecimal(s string) (Decimal, error) {
+	invalid := fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	digits := s
+	if strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+		digits = s[1:]
+	}
+	exp := 0
+	if i := strings.IndexAny(digits, "eE"); i >= 0 {
+		e, err := strconv.Atoi(digits[i+1:])
+		if err != nil || e < -maxExponent || e > maxExponent {
+			return Decimal{}, invalid
+		}
+		digits, exp = digits[:i], e
+	}
+	intPart, fracPart, _ := strings.Cut(digits, ".")
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return Decimal{}, invalid
+	}
+
+	coef, _ := new(big.Int).SetString(intPart+fracPart, 10)
+	if strings.HasPrefix(s, "-") {
+		coef.Neg(coef)
+	}
+	d := Decimal{coef: coef, scale: len(fracPart) - exp}
+	if d.scale < 0 {
+		d = Decimal{coef: coef.Mul(coef, pow10(-d.scale))}
+	}
+	return d, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics on invalid input.
+// It is meant for constants in code and tests.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// Cmp compares d and e and returns -1, 0 or +1. Scale does not matter,
+// so 1.5 and 1.50 are equal.
+func (d Decimal) Cmp(e Decimal) int {
+	a, b, _ := align(d, e)
+	return a.Cmp(b)
+}
+
+// Add returns d + e exactly; the scale is the larger of both scales.
+func (d Decimal) Add(e Decimal) Decimal {
+	a, b, scale := align(d, e)
+	return Decimal{coef: a.Add(a, b), scale: scale}
+}
+
+// Sub returns d - e exactly; the scale is the larger of both scales.
+func (d Decimal) Sub(e Decimal) Decimal {
+	a, b, scale := align(d, e)
+	return Decimal{coef: a.Sub(a, b), scale: scale}
+}
+
+// Mul returns d * e exactly; the scale is the sum of both scales.
+// Use Round to bring the result back to a fixed number of digits.
+func (d Decimal) Mul(e Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.int(), e.int()), scale: d.scale + e.scale}
+}
+
+// Div returns d / e with scale digits after the point, rounded according to
+// mode, or an error matching ErrDiviedByZero.
+func (d Decimal) Div(e Decimal, scale int, mode RoundingMode) (Decimal, error) {
+	if e.Sign() == 0 {
+		return Decimal{}, fmt.Errorf("%v / 0: %w", d, ErrDiviedByZero)
+	}
+	scale = max(scale, 0)
+
+	// d/e * 10^scale = d.coef * 10^(scale + e.scale - d.scale) / e.coef
+	num, den := new(big.Int).Set(d.int()), new(big.Int).Set(e.int())
+	if shift := scale + e.scale - d.scale; shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+
+	coef, err := divRound(num, den, mode)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{coef: coef, scale: scale}, nil
+}
+
+// Round returns d with exactly scale digits after the point, rounded
+// according to mode when digits are dropped.
+func (d Decimal) Round(scale int, mode RoundingMode) (Decimal, error) {
+	scale = max(scale, 0)
+	if scale >= d.scale {
+		return d.rescale(scale), nil
+	}
+	coef, err := divRound(d.int(), pow10(d.scale-scale), mode)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{coef: coef, scale: scale}, nil
+}
+
+// String returns d in plain notation with all of its scale digits, like "-0.50".
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// MarshalJSON encodes d as a JSON string so that no precision is lost in
+// consumers that read numbers as float64.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts a decimal as a JSON string or number, in the forms
+// ParseDecimal accepts, including exponents such as 1e3.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale returns d with a larger scale and the same value.
+func (d Decimal) rescale(scale int) Decimal {
+	coef := new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+	return Decimal{coef: coef, scale: scale}
+}
+
+// align returns copies of the coefficients of d and e at a common scale.
+func align(d, e Decimal) (a, b *big.Int, scale int) {
+	scale = max(d.scale, e.scale)
+	return d.rescale(scale).coef, e.rescale(scale).coef, scale
+}
+
+// divRound returns n / d rounded according to mode.
+func divRound(n, d *big.Int, mode RoundingMode) (*big.Int, error) {
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	if r.Sign() == 0 {
+		return q, nil
+	}
+
+	negative := n.Sign() != d.Sign()
+	awayFromZero := false
+	switch mode {
+	case Truncate:
+	case Floor:
+		awayFromZero = negative
+	case Ceil:
+		awayFromZero = !negative
+	case HalfEven:
+		cmp := new(big.Int).Lsh(new(big.Int).Abs(r), 1).CmpAbs(d)
+		awayFromZero = cmp > 0 || (cmp == 0 && q.Bit(0) == 1)
+	default:
+		return nil, fmt.Errorf("unknown rounding mode %v", mode)
+	}
+
+	if awayFromZero {
+		if negative {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q, nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,215 @@
+package mathutils
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		scale    int
+		err      error
+	}{
+		{"0", "0", 0, nil},
+		{"12.340", "12.340", 3, nil},
+		{"-0.5", "-0.5", 1, nil},
+		{"+7", "7", 0, nil},
+		{".25", "0.25", 2, nil},
+		{"3.", "3", 0, nil},
+		{"-0.00", "0.00", 2, nil},
+		{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789", 9, nil},
+		{"", "", 0, ErrInvalidDecimal},
+		{".", "", 0, ErrInvalidDecimal},
+		{"-", "", 0, ErrInvalidDecimal},
+		{"+-1", "", 0, ErrInvalidDecimal},
+		{"1.2.3", "", 0, ErrInvalidDecimal},
+		{"1e3", "1000", 0, nil},
+		{"1.50e1", "15.0", 1, nil},
+		{"-1.5E-3", "-0.0015", 4, nil},
+		{"2.5e+2", "250", 0, nil},
+		{"1e", "", 0, ErrInvalidDecimal},
+		{"e3", "", 0, ErrInvalidDecimal},
+		{"1e1.5", "", 0, ErrInvalidDecimal},
+		{"1e999999999", "", 0, ErrInvalidDecimal},
+		{"abc", "", 0, ErrInvalidDecimal},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if !errors.Is(err, test.err) {
+			t.Errorf("ParseDecimal(%q) error = %v; want %v", test.input, err, test.err)
+			continue
+		}
+		if err == nil && (d.String() != test.expected || d.Scale() != test.scale) {
+			t.Errorf("ParseDecimal(%q) = %s (scale %d); want %s (scale %d)", test.input, d, d.Scale(), test.expected, test.scale)
+		}
+	}
+}
+
+func TestNewDecimal(t *testing.T) {
+	tests := []struct {
+		coef     int64
+		scale    int
+		expected string
+	}{
+		{1999, 2, "19.99"},
+		{-5, 3, "-0.005"},
+		{15, 0, "15"},
+		{15, -1, "150"},
+		{-7, -3, "-7000"},
+	}
+
+	for _, test := range tests {
+		d := NewDecimal(test.coef, test.scale)
+		if d.String() != test.expected || d.Cmp(MustParseDecimal(test.expected)) != 0 {
+			t.Errorf("NewDecimal(%d, %d) = %s; want %s", test.coef, test.scale, d, test.expected)
+		}
+	}
+	if d := NewDecimal(15, -1); d.Cmp(MustParseDecimal("15e1")) != 0 {
+		t.Errorf("NewDecimal(15, -1) = %s; want the same as ParseDecimal(\"15e1\")", d)
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		add, sub string
+		mul      string
+	}{
+		{"0.1", "0.2", "0.3", "-0.1", "0.02"},
+		{"19.99", "3", "22.99", "16.99", "59.97"},
+		{"-1.50", "0.5", "-1.00", "-2.00", "-0.750"},
+		{"99999999999999999999", "0.01", "99999999999999999999.01", "99999999999999999998.99", "999999999999999999.99"},
+	}
+
+	for _, test := range tests {
+		a, b := MustParseDecimal(test.a), MustParseDecimal(test.b)
+		if result := a.Add(b).String(); result != test.add {
+			t.Errorf("%s + %s = %s; want %s", test.a, test.b, result, test.add)
+		}
+		if result := a.Sub(b).String(); result != test.sub {
+			t.Errorf("%s - %s = %s; want %s", test.a, test.b, result, test.sub)
+		}
+		if result := a.Mul(b).String(); result != test.mul {
+			t.Errorf("%s * %s = %s; want %s", test.a, test.b, result, test.mul)
+		}
+	}
+
+	var zero Decimal
+	if result := zero.Add(MustParseDecimal("1.5")).String(); result != "1.5" {
+		t.Errorf("Decimal{} + 1.5 = %s; want 1.5", result)
+	}
+}
+
+func TestDecimalDiv(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		scale    int
+		mode     RoundingMode
+		expected string
+	}{
+		{"10", "4", 2, Truncate, "2.50"},
+		{"1", "3", 4, HalfEven, "0.3333"},
+		{"2", "3", 4, HalfEven, "0.6667"},
+		{"2", "3", 4, Truncate, "0.6666"},
+		{"-2", "3", 2, Floor, "-0.67"},
+		{"-2", "3", 2, Ceil, "-0.66"},
+		{"0.125", "1", 2, HalfEven, "0.12"},
+		{"0.135", "1", 2, HalfEven, "0.14"},
+		{"100.00", "0.03", 0, HalfEven, "3333"},
+		{"7", "0.5", 0, Truncate, "14"},
+	}
+
+	for _, test := range tests {
+		a, b := MustParseDecimal(test.a), MustParseDecimal(test.b)
+		result, err := a.Div(b, test.scale, test.mode)
+		if err != nil || result.String() != test.expected {
+			t.Errorf("%s / %s (scale %d, %v) = %s, %v; want %s", test.a, test.b, test.scale, test.mode, result, err, test.expected)
+		}
+	}
+
+	if _, err := MustParseDecimal("1").Div(MustParseDecimal("0.00"), 2, HalfEven); !errors.Is(err, ErrDiviedByZero) {
+		t.Errorf("1 / 0.00 error = %v; want ErrDiviedByZero", err)
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	tests := []struct {
+		input    string
+		scale    int
+		mode     RoundingMode
+		expected string
+	}{
+		{"2.345", 2, HalfEven, "2.34"},
+		{"2.355", 2, HalfEven, "2.36"},
+		{"2.3451", 2, HalfEven, "2.35"},
+		{"-2.345", 2, HalfEven, "-2.34"},
+		{"-2.341", 2, Floor, "-2.35"},
+		{"2.341", 2, Ceil, "2.35"},
+		{"2.349", 2, Truncate, "2.34"},
+		{"2.5", 3, HalfEven, "2.500"},
+		{"0.5", 0, HalfEven, "0"},
+		{"1.5", 0, HalfEven, "2"},
+	}
+
+	for _, test := range tests {
+		result, err := MustParseDecimal(test.input).Round(test.scale, test.mode)
+		if err != nil || result.String() != test.expected {
+			t.Errorf("Round(%s, %d, %v) = %s, %v; want %s", test.input, test.scale, test.mode, result, err, test.expected)
+		}
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.5", "1.50", 0},
+		{"1.49", "1.5", -1},
+		{"-1", "-2", 1},
+	}
+
+	for _, test := range tests {
+		if result := MustParseDecimal(test.a).Cmp(MustParseDecimal(test.b)); result != test.expected {
+			t.Errorf("Cmp(%s, %s) = %d; want %d", test.a, test.b, result, test.expected)
+		}
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	type invoice struct {
+		Total Decimal `json:"total"`
+	}
+
+	data, err := json.Marshal(invoice{Total: MustParseDecimal("1234.50")})
+	if err != nil || string(data) != `{"total":"1234.50"}` {
+		t.Errorf("Marshal = %s, %v; want %s", data, err, `{"total":"1234.50"}`)
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"total":"0.10"}`, "0.10"},
+		{`{"total":12.5}`, "12.5"},
+		{`{"total":null}`, "0"},
+		{`{"total":1e3}`, "1000"},
+		{`{"total":-2.5E-2}`, "-0.025"},
+	}
+
+	for _, test := range tests {
+		var inv invoice
+		if err := json.Unmarshal([]byte(test.input), &inv); err != nil || inv.Total.String() != test.expected {
+			t.Errorf("Unmarshal(%s) = %s, %v; want %s", test.input, inv.Total, err, test.expected)
+		}
+	}
+
+	var inv invoice
+	if err := json.Unmarshal([]byte(`{"total":"ten"}`), &inv); !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("Unmarshal of invalid decimal error = %v; want ErrInvalidDecimal", err)
+	}
+}
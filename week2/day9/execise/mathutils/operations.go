@@ -1,16 +1,172 @@
 package mathutils
 
-import "errors"
+import (
+	"errors"
+	"execise/checked"
+	"fmt"
+	"math"
+)
 
-var ErrDiviedByZero = errors.New("cannot divide by zero")
+// ErrDiviedByZero is returned when dividing by zero. It is the same error as
+// checked.ErrDivideByZero, so errors.Is matches errors from either package.
+var ErrDiviedByZero = checked.ErrDivideByZero
 
+// ErrNotFinite is returned when a floating-point division yields Inf or NaN.
+var ErrNotFinite = errors.New("result is not a finite number")
+
+// Float is the set of floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is the set of integer and floating-point types.
+type Number interface {
+	checked.Integer | Float
+}
+
+// RoundingMode selects how DivideRound rounds an inexact integer quotient.
+type RoundingMode int
+
+const (
+	Truncate RoundingMode = iota // toward zero, like Go's / operator
+	Floor                        // toward negative infinity
+	Ceil                         // toward positive infinity
+	HalfEven                     // to the nearest integer, ties to the even one
+)
+
+func (m RoundingMode) String() string {
+	switch m {
+	case Truncate:
+		return "Truncate"
+	case Floor:
+		return "Floor"
+	case Ceil:
+		return "Ceil"
+	case HalfEven:
+		return "HalfEven"
+	}
+	return fmt.Sprintf("RoundingMode(%d)", int(m))
+}
+
+// Multiply returns a * b, wrapping around on overflow.
 func Multiply(a, b int) int {
 	return a * b
 }
 
-func Divide(a, b int) (int, error) {
+// MultiplyChecked returns a * b, or an error wrapping checked.ErrOverflow.
+func MultiplyChecked(a, b int) (int, error) {
+	return checked.Mul(a, b)
+}
+
+// Divide returns a / b. Integer division truncates toward zero; use
+// DivideRound for other rounding modes.
+// It returns an error matching ErrDiviedByZero when b is 0, checked.ErrOverflow
+// for the most negative integer divided by -1, and ErrNotFinite when a
+// floating-point result is Inf or NaN.
+func Divide[T Number](a, b T) (T, error) {
 	if b == 0 {
-		return 0, ErrDiviedByZero
+		return 0, fmt.Errorf("%v / 0: %w", a, ErrDiviedByZero)
+	}
+
+	if isInteger[T]() {
+		return divideInteger(a, b)
+	}
+
+	q := a / b
+	if f := float64(q); math.IsInf(f, 0) || math.IsNaN(f) {
+		return 0, fmt.Errorf("%v / %v = %v: %w", a, b, q, ErrNotFinite)
+	}
+	return q, nil
+}
+
+// DivideRound returns a / b rounded according to mode.
+func DivideRound[T checked.Integer](a, b T, mode RoundingMode) (T, error) {
+	if mode < Truncate || mode > HalfEven {
+		return 0, fmt.Errorf("unknown rounding mode %v", mode)
+	}
+	q, err := checked.Div(a, b)
+	if err != nil {
+		return 0, err
 	}
-	return (a / b), nil
+	r := a % b
+	if r == 0 {
+		return q, nil
+	}
+
+	negative := (a < 0) != (b < 0)
+	away := q + 1 // one step away from zero
+	if negative {
+		away = q - 1
+	}
+
+	switch mode {
+	case Truncate:
+		return q, nil
+	case Floor:
+		if negative {
+			return away, nil
+		}
+		return q, nil
+	case Ceil:
+		if negative {
+			return q, nil
+		}
+		return away, nil
+	}
+
+	// HalfEven: compare the remainder with its distance to the divisor
+	// without computing |b|, which overflows for the most negative value.
+	rem := r
+	if r < 0 {
+		rem = -r
+	}
+	other := b - rem
+	if b < 0 {
+		other = -(b + rem)
+	}
+	if rem > other || (rem == other && q%2 != 0) {
+		return away, nil
+	}
+	return q, nil
+}
+
+// DivMod returns the Euclidean quotient and remainder of a and b:
+// a == b*q + r with 0 <= r < |b|.
+func DivMod[T checked.Integer](a, b T) (q, r T, err error) {
+	q, err = checked.Div(a, b)
+	if err != nil {
+		return 0, 0, err
+	}
+	r = a % b
+	if r < 0 {
+		if b > 0 {
+			q, r = q-1, r+b
+		} else {
+			q, r = q+1, r-b
+		}
+	}
+	return q, r, nil
+}
+
+// divideInteger returns a / b for an integer type T through checked.Div on
+// the 64-bit type of the same signedness. A quotient that does not fit back
+// into T, like the most negative int8 divided by -1, is an overflow too.
+func divideInteger[T Number](a, b T) (T, error) {
+	if a >= 0 && b >= 0 {
+		q, err := checked.Div(uint64(a), uint64(b))
+		return T(q), err
+	}
+	q, err := checked.Div(int64(a), int64(b))
+	if err != nil {
+		return 0, err
+	}
+	if int64(T(q)) != q {
+		return 0, fmt.Errorf("%v / %v: %w", a, b, checked.ErrOverflow)
+	}
+	return T(q), nil
+}
+
+// isInteger reports whether T is an integer type.
+func isInteger[T Number]() bool {
+	return T(1)/T(2) == 0
 }
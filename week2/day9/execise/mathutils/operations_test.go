@@ -0,0 +1,140 @@
+package mathutils
+
+import (
+	"errors"
+	"execise/checked"
+	"math"
+	"testing"
+)
+
+func TestDivide(t *testing.T) {
+	tests := []struct {
+		a, b     int
+		expected int
+		err      error
+	}{
+		{10, 5, 2, nil},
+		{-7, 2, -3, nil},
+		{10, 0, 0, ErrDiviedByZero},
+		{math.MinInt, -1, 0, checked.ErrOverflow},
+	}
+
+	for _, test := range tests {
+		result, err := Divide(test.a, test.b)
+		if result != test.expected || !errors.Is(err, test.err) {
+			t.Errorf("Divide(%d, %d) = %d, %v; want %d, %v", test.a, test.b, result, err, test.expected, test.err)
+		}
+	}
+
+	if _, err := checked.Mod(int8(1), 0); !errors.Is(err, ErrDiviedByZero) {
+		t.Errorf("checked.Mod(1, 0) error %v does not match ErrDiviedByZero", err)
+	}
+	if result, err := Divide[uint8](255, 2); result != 127 || err != nil {
+		t.Errorf("Divide[uint8](255, 2) = %d, %v; want 127, nil", result, err)
+	}
+	if result, err := Divide[uint64](math.MaxUint64, 1); result != math.MaxUint64 || err != nil {
+		t.Errorf("Divide[uint64](MaxUint64, 1) = %d, %v; want MaxUint64, nil", result, err)
+	}
+	if _, err := Divide[int8](math.MinInt8, -1); !errors.Is(err, checked.ErrOverflow) {
+		t.Errorf("Divide[int8](MinInt8, -1) error = %v; want ErrOverflow", err)
+	}
+	if result, err := Divide[int8](math.MinInt8, 2); result != -64 || err != nil {
+		t.Errorf("Divide[int8](MinInt8, 2) = %d, %v; want -64, nil", result, err)
+	}
+}
+
+func TestDivideFloat(t *testing.T) {
+	tests := []struct {
+		a, b     float64
+		expected float64
+		err      error
+	}{
+		{10, 4, 2.5, nil},
+		{-1, 3, -1.0 / 3, nil},
+		{1, 0, 0, ErrDiviedByZero},
+		{0, 0, 0, ErrDiviedByZero},
+		{math.MaxFloat64, 0.5, 0, ErrNotFinite},
+		{math.Inf(1), 2, 0, ErrNotFinite},
+		{math.NaN(), 2, 0, ErrNotFinite},
+		{math.Inf(1), math.Inf(-1), 0, ErrNotFinite},
+	}
+
+	for _, test := range tests {
+		result, err := Divide(test.a, test.b)
+		if result != test.expected || !errors.Is(err, test.err) {
+			t.Errorf("Divide(%v, %v) = %v, %v; want %v, %v", test.a, test.b, result, err, test.expected, test.err)
+		}
+	}
+
+	if _, err := Divide[float32](math.MaxFloat32, 0.1); !errors.Is(err, ErrNotFinite) {
+		t.Errorf("Divide[float32](MaxFloat32, 0.1) error = %v; want ErrNotFinite", err)
+	}
+}
+
+func TestDivideRound(t *testing.T) {
+	tests := []struct {
+		a, b                        int64
+		truncate, floor, ceil, even int64
+	}{
+		{7, 2, 3, 3, 4, 4},
+		{5, 2, 2, 2, 3, 2},
+		{-7, 2, -3, -4, -3, -4},
+		{-5, 2, -2, -3, -2, -2},
+		{7, -2, -3, -4, -3, -4},
+		{-7, -2, 3, 3, 4, 4},
+		{10, 3, 3, 3, 4, 3},
+		{11, 3, 3, 3, 4, 4},
+		{-11, 3, -3, -4, -3, -4},
+		{6, 3, 2, 2, 2, 2},
+		{math.MaxInt64, math.MinInt64, 0, -1, 0, -1},
+		{math.MinInt64, math.MaxInt64, -1, -2, -1, -1},
+		{math.MinInt64 / 2, math.MinInt64, 0, 0, 1, 0},
+		{math.MinInt64/2 + 1, math.MinInt64, 0, 0, 1, 0},
+		{math.MinInt64/2 - 1, math.MinInt64, 0, 0, 1, 1},
+	}
+
+	for _, test := range tests {
+		for mode, expected := range map[RoundingMode]int64{Truncate: test.truncate, Floor: test.floor, Ceil: test.ceil, HalfEven: test.even} {
+			if result, err := DivideRound(test.a, test.b, mode); result != expected || err != nil {
+				t.Errorf("DivideRound(%d, %d, %v) = %d, %v; want %d", test.a, test.b, mode, result, err, expected)
+			}
+		}
+	}
+
+	if _, err := DivideRound(1, 0, Floor); !errors.Is(err, ErrDiviedByZero) {
+		t.Errorf("DivideRound(1, 0, Floor) error = %v; want ErrDiviedByZero", err)
+	}
+	if result, err := DivideRound[uint](7, 2, HalfEven); result != 4 || err != nil {
+		t.Errorf("DivideRound[uint](7, 2, HalfEven) = %d, %v; want 4, nil", result, err)
+	}
+	for _, a := range []int{6, 7} {
+		if _, err := DivideRound(a, 3, RoundingMode(9)); err == nil {
+			t.Errorf("DivideRound(%d, 3, RoundingMode(9)) returned no error", a)
+		}
+	}
+}
+
+func TestDivMod(t *testing.T) {
+	tests := []struct {
+		a, b int
+		q, r int
+		err  error
+	}{
+		{7, 2, 3, 1, nil},
+		{-7, 2, -4, 1, nil},
+		{7, -2, -3, 1, nil},
+		{-7, -2, 4, 1, nil},
+		{6, -3, -2, 0, nil},
+		{math.MinInt, math.MinInt, 1, 0, nil},
+		{-1, math.MinInt, 1, math.MaxInt, nil},
+		{1, 0, 0, 0, ErrDiviedByZero},
+		{math.MinInt, -1, 0, 0, checked.ErrOverflow},
+	}
+
+	for _, test := range tests {
+		q, r, err := DivMod(test.a, test.b)
+		if q != test.q || r != test.r || !errors.Is(err, test.err) {
+			t.Errorf("DivMod(%d, %d) = %d, %d, %v; want %d, %d, %v", test.a, test.b, q, r, err, test.q, test.r, test.err)
+		}
+	}
+}
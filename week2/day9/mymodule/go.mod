@@ -3,8 +3,12 @@ module mymodule
 go 1.23.5
 
 require (
-	github.com/fatih/color v1.18.0 // indirect
-	github.com/mattn/go-colorable v0.1.13 // indirect
-	github.com/mattn/go-isatty v0.0.20 // indirect
-	golang.org/x/sys v0.25.0 // indirect
+	execise v0.0.0-00010101000000-000000000000
+	github.com/fatih/color v1.18.0
+	github.com/mattn/go-colorable v0.1.13
+	github.com/mattn/go-isatty v0.0.20
 )
+
+require golang.org/x/sys v0.25.0 // indirect
+
+replace execise => ../execise
@@ -1,19 +1,43 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"mymodule/mathops"
-
-	"github.com/fatih/color"
+	"mymodule/output"
+	"os"
 )
 
 func main() {
+	var colorMode output.Mode
+	flag.Var(&colorMode, "color", output.FlagUsage)
+	flag.Parse()
+	out := output.New(os.Stdout, colorMode)
+
 	sum := mathops.Add(10, 5)
 	diff := mathops.Subtract(10, 5)
 
 	fmt.Println("Sum:", sum)
 	fmt.Println("Difference:", diff)
 
-	color.Cyan("Hello, World in Cyan!")
-	fmt.Println("This is normal text.")
+	m, err := mathops.FromRows([][]float64{{4, 7}, {2, 6}})
+	if err != nil {
+		out.Error("Matrix:", err)
+		return
+	}
+	det, err := m.Determinant()
+	if err != nil {
+		out.Error("Determinant:", err)
+		return
+	}
+	fmt.Println("Determinant:", det)
+	inv, err := m.Inverse()
+	if err != nil {
+		out.Error("Inverse:", err)
+		return
+	}
+	fmt.Printf("Inverse:\n%v\n", inv)
+
+	out.Success("Hello, World in color!")
+	out.Muted("This is muted text.")
 }
@@ -1,11 +1,29 @@
+// Package mathops provides integer arithmetic and generic vectors and matrices.
+//
+// Add and Subtract, like the integer Vector and Matrix operations, wrap
+// around on overflow like Go's operators; AddChecked and SubtractChecked go
+// through the checked package of the execise module and report overflow as an
+// error wrapping checked.ErrOverflow instead.
 package mathops
 
-// Add function adds two numbers
+import "execise/checked"
+
+// Add function adds two numbers.
 func Add(a, b int) int {
 	return a + b
 }
 
-// Subtract function subtracts two numbers
+// Subtract function subtracts two numbers.
 func Subtract(a, b int) int {
 	return a - b
 }
+
+// AddChecked adds two numbers, or returns an error if the sum overflows.
+func AddChecked(a, b int) (int, error) {
+	return checked.Add(a, b)
+}
+
+// SubtractChecked subtracts two numbers, or returns an error if the difference overflows.
+func SubtractChecked(a, b int) (int, error) {
+	return checked.Sub(a, b)
+}
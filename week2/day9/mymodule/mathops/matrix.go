@@ -0,0 +1,336 @@
+package mathops
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"execise/mathutils"
+)
+
+var (
+	ErrDimensionMismatch = errors.New("dimension mismatch")
+	ErrNotSquare         = errors.New("matrix is not square")
+	ErrSingular          = errors.New("matrix is singular")
+)
+
+// epsilon is the float64 machine epsilon. Inverse treats a pivot as zero when
+// it is below n·epsilon times the largest element of the pivot's original row,
+// which is as close to zero as rounding errors of the elimination can get.
+const epsilon = 0x1p-52
+
+// Matrix is a dense rows × cols matrix of numbers stored in row-major order.
+// Copies of a Matrix share their elements; use Clone for an independent copy.
+type Matrix[T mathutils.Number] struct {
+	rows, cols int
+	data       []T
+}
+
+// NewMatrix returns a rows × cols matrix of zeros.
+func NewMatrix[T mathutils.Number](rows, cols int) Matrix[T] {
+	return Matrix[T]{rows: rows, cols: cols, data: make([]T, rows*cols)}
+}
+
+// Identity returns the n × n identity matrix.
+func Identity[T mathutils.Number](n int) Matrix[T] {
+	m := NewMatrix[T](n, n)
+	for i := 0; i < n; i++ {
+		m.data[i*n+i] = 1
+	}
+	return m
+}
+
+// FromRows builds a matrix from a slice of rows, which must all have the same length.
+func FromRows[T mathutils.Number](rows [][]T) (Matrix[T], error) {
+	if len(rows) == 0 {
+		return Matrix[T]{}, nil
+	}
+	m := NewMatrix[T](len(rows), len(rows[0]))
+	for i, row := range rows {
+		if len(row) != m.cols {
+			return Matrix[T]{}, fmt.Errorf("row %d has %d columns, want %d: %w", i, len(row), m.cols, ErrDimensionMismatch)
+		}
+		copy(m.data[i*m.cols:], row)
+	}
+	return m, nil
+}
+
+// Rows returns the number of rows of m.
+func (m Matrix[T]) Rows() int { return m.rows }
+
+// Cols returns the number of columns of m.
+func (m Matrix[T]) Cols() int { return m.cols }
+
+// At returns the element in row i and column j.
+func (m Matrix[T]) At(i, j int) T {
+	return m.data[m.index(i, j)]
+}
+
+// Set sets the element in row i and column j.
+func (m Matrix[T]) Set(i, j int, v T) {
+	m.data[m.index(i, j)] = v
+}
+
+func (m Matrix[T]) index(i, j int) int {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic(fmt.Sprintf("mathops: index (%d, %d) out of range for %d×%d matrix", i, j, m.rows, m.cols))
+	}
+	return i*m.cols + j
+}
+
+// Row returns a copy of row i.
+func (m Matrix[T]) Row(i int) Vector[T] {
+	m.index(i, 0)
+	return append(Vector[T](nil), m.data[i*m.cols:(i+1)*m.cols]...)
+}
+
+// Clone returns an independent copy of m.
+func (m Matrix[T]) Clone() Matrix[T] {
+	return Matrix[T]{rows: m.rows, cols: m.cols, data: append([]T(nil), m.data...)}
+}
+
+// Equal reports whether m and b have the same dimensions and elements.
+func (m Matrix[T]) Equal(b Matrix[T]) bool {
+	if m.rows != b.rows || m.cols != b.cols {
+		return false
+	}
+	for i := range m.data {
+		if m.data[i] != b.data[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats m one row per line, e.g. "[1 2]\n[3 4]".
+func (m Matrix[T]) String() string {
+	var sb strings.Builder
+	for i := 0; i < m.rows; i++ {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		fmt.Fprint(&sb, []T(m.data[i*m.cols:(i+1)*m.cols]))
+	}
+	return sb.String()
+}
+
+// Add returns the element-wise sum m + b.
+func (m Matrix[T]) Add(b Matrix[T]) (Matrix[T], error) {
+	if m.rows != b.rows || m.cols != b.cols {
+		return Matrix[T]{}, fmt.Errorf("add %d×%d and %d×%d matrices: %w", m.rows, m.cols, b.rows, b.cols, ErrDimensionMismatch)
+	}
+	sum := NewMatrix[T](m.rows, m.cols)
+	for i := range m.data {
+		sum.data[i] = m.data[i] + b.data[i]
+	}
+	return sum, nil
+}
+
+// Transpose returns the transpose of m.
+func (m Matrix[T]) Transpose() Matrix[T] {
+	t := NewMatrix[T](m.cols, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			t.data[j*m.rows+i] = m.data[i*m.cols+j]
+		}
+	}
+	return t
+}
+
+// Multiply returns the matrix product m × b.
+func (m Matrix[T]) Multiply(b Matrix[T]) (Matrix[T], error) {
+	if m.cols != b.rows {
+		return Matrix[T]{}, fmt.Errorf("multiply %d×%d by %d×%d matrix: %w", m.rows, m.cols, b.rows, b.cols, ErrDimensionMismatch)
+	}
+	product := NewMatrix[T](m.rows, b.cols)
+	for i := 0; i < m.rows; i++ {
+		m.multiplyRow(b, product, i)
+	}
+	return product, nil
+}
+
+// MultiplyParallel is Multiply with the rows of the product computed
+// concurrently by workers goroutines. It pays off for large matrices only.
+func (m Matrix[T]) MultiplyParallel(b Matrix[T], workers int) (Matrix[T], error) {
+	if m.cols != b.rows {
+		return Matrix[T]{}, fmt.Errorf("multiply %d×%d by %d×%d matrix: %w", m.rows, m.cols, b.rows, b.cols, ErrDimensionMismatch)
+	}
+	product := NewMatrix[T](m.rows, b.cols)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < max(workers, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				m.multiplyRow(b, product, i)
+			}
+		}()
+	}
+
+	for i := 0; i < m.rows; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return product, nil
+}
+
+// multiplyRow computes row i of product = m × b.
+// The i-k-j loop order walks both b and product row by row, which is cache friendly.
+func (m Matrix[T]) multiplyRow(b, product Matrix[T], i int) {
+	out := product.data[i*b.cols : (i+1)*b.cols]
+	for k := 0; k < m.cols; k++ {
+		a := m.data[i*m.cols+k]
+		if a == 0 {
+			continue
+		}
+		row := b.data[k*b.cols : (k+1)*b.cols]
+		for j, v := range row {
+			out[j] += a * v
+		}
+	}
+}
+
+// MultiplyVector returns the matrix-vector product m × v.
+func (m Matrix[T]) MultiplyVector(v Vector[T]) (Vector[T], error) {
+	if m.cols != len(v) {
+		return nil, fmt.Errorf("multiply %d×%d matrix by vector of length %d: %w", m.rows, m.cols, len(v), ErrDimensionMismatch)
+	}
+	product := make(Vector[T], m.rows)
+	for i := range product {
+		product[i], _ = Vector[T](m.data[i*m.cols : (i+1)*m.cols]).Dot(v)
+	}
+	return product, nil
+}
+
+// float64s returns a float64 copy of m.
+func (m Matrix[T]) float64s() Matrix[float64] {
+	f := NewMatrix[float64](m.rows, m.cols)
+	for i, v := range m.data {
+		f.data[i] = float64(v)
+	}
+	return f
+}
+
+// rowScales returns the largest absolute element of every row of m.
+func rowScales(m Matrix[float64]) []float64 {
+	scales := make([]float64, m.rows)
+	for i := range scales {
+		for _, v := range m.data[i*m.cols : (i+1)*m.cols] {
+			scales[i] = max(scales[i], math.Abs(v))
+		}
+	}
+	return scales
+}
+
+// pivot finds the row at or below col with the largest absolute value in col
+// and swaps it into place in a and, if it is not empty, in b and scales.
+// It reports whether a row had to be swapped, and returns false for ok when
+// the pivot is zero, or with scales, below n·epsilon times the scale of its row.
+func pivot(a, b Matrix[float64], scales []float64, col int) (swapped, ok bool) {
+	best := col
+	for r := col + 1; r < a.rows; r++ {
+		if math.Abs(a.data[r*a.cols+col]) > math.Abs(a.data[best*a.cols+col]) {
+			best = r
+		}
+	}
+	cutoff := 0.0
+	if scales != nil {
+		cutoff = float64(a.rows) * epsilon * scales[best]
+	}
+	if math.Abs(a.data[best*a.cols+col]) <= cutoff {
+		return false, false
+	}
+	if best == col {
+		return false, true
+	}
+	swapRows(a, best, col)
+	if b.cols > 0 {
+		swapRows(b, best, col)
+	}
+	if scales != nil {
+		scales[best], scales[col] = scales[col], scales[best]
+	}
+	return true, true
+}
+
+func swapRows(m Matrix[float64], r1, r2 int) {
+	row1 := m.data[r1*m.cols : (r1+1)*m.cols]
+	row2 := m.data[r2*m.cols : (r2+1)*m.cols]
+	for j := range row1 {
+		row1[j], row2[j] = row2[j], row1[j]
+	}
+}
+
+// Determinant returns the determinant of the square matrix m, computed in
+// float64 by Gaussian elimination with partial pivoting as the product of
+// the pivots. It is 0 only when a pivot is exactly 0; for a nearly singular
+// matrix it is a tiny value dominated by rounding errors.
+func (m Matrix[T]) Determinant() (float64, error) {
+	if m.rows != m.cols {
+		return 0, fmt.Errorf("determinant of %d×%d matrix: %w", m.rows, m.cols, ErrNotSquare)
+	}
+	a := m.float64s()
+	n := a.rows
+	det := 1.0
+	for col := 0; col < n; col++ {
+		swapped, ok := pivot(a, Matrix[float64]{}, nil, col)
+		if !ok {
+			return 0, nil
+		}
+		if swapped {
+			det = -det
+		}
+		p := a.data[col*n+col]
+		det *= p
+		for r := col + 1; r < n; r++ {
+			factor := a.data[r*n+col] / p
+			for j := col; j < n; j++ {
+				a.data[r*n+j] -= factor * a.data[col*n+j]
+			}
+		}
+	}
+	return det, nil
+}
+
+// Inverse returns the inverse of the square matrix m, computed in float64 by
+// Gauss-Jordan elimination with partial pivoting.
+// It returns ErrSingular if m has no inverse.
+func (m Matrix[T]) Inverse() (Matrix[float64], error) {
+	if m.rows != m.cols {
+		return Matrix[float64]{}, fmt.Errorf("inverse of %d×%d matrix: %w", m.rows, m.cols, ErrNotSquare)
+	}
+	a := m.float64s()
+	n := a.rows
+	inv := Identity[float64](n)
+	scales := rowScales(a)
+	for col := 0; col < n; col++ {
+		if _, ok := pivot(a, inv, scales, col); !ok {
+			return Matrix[float64]{}, fmt.Errorf("inverse of %d×%d matrix: %w", n, n, ErrSingular)
+		}
+		p := a.data[col*n+col]
+		for j := 0; j < n; j++ {
+			a.data[col*n+j] /= p
+			inv.data[col*n+j] /= p
+		}
+		for r := 0; r < n; r++ {
+			if r == col {
+				continue
+			}
+			factor := a.data[r*n+col]
+			if factor == 0 {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				a.data[r*n+j] -= factor * a.data[col*n+j]
+				inv.data[r*n+j] -= factor * inv.data[col*n+j]
+			}
+		}
+	}
+	return inv, nil
+}
@@ -0,0 +1,223 @@
+package mathops
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func mustRows[T int | int64 | float64](t testing.TB, rows [][]T) Matrix[T] {
+	t.Helper()
+	m, err := FromRows(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestVector(t *testing.T) {
+	v := Vector[int]{1, 2, 3}
+	w := Vector[int]{4, 5, 6}
+
+	if sum, err := v.Add(w); err != nil || fmt.Sprint(sum) != "[5 7 9]" {
+		t.Errorf("Add return %v, %v, want [5 7 9]", sum, err)
+	}
+	if dot, err := v.Dot(w); err != nil || dot != 32 {
+		t.Errorf("Dot return %d, %v, want 32", dot, err)
+	}
+	if cross, err := v.Cross(w); err != nil || fmt.Sprint(cross) != "[-3 6 -3]" {
+		t.Errorf("Cross return %v, %v, want [-3 6 -3]", cross, err)
+	}
+	if scaled := v.Scale(2); fmt.Sprint(scaled) != "[2 4 6]" {
+		t.Errorf("Scale(2) return %v, want [2 4 6]", scaled)
+	}
+
+	if _, err := v.Add(Vector[int]{1}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Add of different lengths error %v, want ErrDimensionMismatch", err)
+	}
+	if _, err := v.Dot(Vector[int]{1}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Dot of different lengths error %v, want ErrDimensionMismatch", err)
+	}
+	if _, err := (Vector[int]{1, 2}).Cross(Vector[int]{3, 4}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Cross of 2-vectors error %v, want ErrDimensionMismatch", err)
+	}
+}
+
+func TestMatrixAddTransposeMultiply(t *testing.T) {
+	a := mustRows(t, [][]int{{1, 2, 3}, {4, 5, 6}})
+	b := mustRows(t, [][]int{{7, 8}, {9, 10}, {11, 12}})
+
+	sum, err := a.Add(a)
+	if want := mustRows(t, [][]int{{2, 4, 6}, {8, 10, 12}}); err != nil || !sum.Equal(want) {
+		t.Errorf("Add return\n%v, %v, want\n%v", sum, err, want)
+	}
+
+	if tr, want := a.Transpose(), mustRows(t, [][]int{{1, 4}, {2, 5}, {3, 6}}); !tr.Equal(want) {
+		t.Errorf("Transpose return\n%v, want\n%v", tr, want)
+	}
+
+	want := mustRows(t, [][]int{{58, 64}, {139, 154}})
+	if product, err := a.Multiply(b); err != nil || !product.Equal(want) {
+		t.Errorf("Multiply return\n%v, %v, want\n%v", product, err, want)
+	}
+	for _, workers := range []int{0, 1, 4} {
+		if product, err := a.MultiplyParallel(b, workers); err != nil || !product.Equal(want) {
+			t.Errorf("MultiplyParallel(%d) return\n%v, %v, want\n%v", workers, product, err, want)
+		}
+	}
+
+	if product, err := a.MultiplyVector(Vector[int]{1, 0, -1}); err != nil || fmt.Sprint(product) != "[-2 -2]" {
+		t.Errorf("MultiplyVector return %v, %v, want [-2 -2]", product, err)
+	}
+}
+
+func TestMatrixDimensionErrors(t *testing.T) {
+	a := NewMatrix[int](2, 3)
+	b := NewMatrix[int](2, 2)
+
+	if _, err := FromRows([][]int{{1, 2}, {3}}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("FromRows of ragged rows error %v, want ErrDimensionMismatch", err)
+	}
+	if _, err := a.Add(b); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Add error %v, want ErrDimensionMismatch", err)
+	}
+	if _, err := a.Multiply(b); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Multiply error %v, want ErrDimensionMismatch", err)
+	}
+	if _, err := a.MultiplyParallel(b, 2); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("MultiplyParallel error %v, want ErrDimensionMismatch", err)
+	}
+	if _, err := a.MultiplyVector(Vector[int]{1}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("MultiplyVector error %v, want ErrDimensionMismatch", err)
+	}
+	if _, err := a.Determinant(); !errors.Is(err, ErrNotSquare) {
+		t.Errorf("Determinant error %v, want ErrNotSquare", err)
+	}
+	if _, err := a.Inverse(); !errors.Is(err, ErrNotSquare) {
+		t.Errorf("Inverse error %v, want ErrNotSquare", err)
+	}
+}
+
+func TestDeterminant(t *testing.T) {
+	tests := []struct {
+		rows     [][]float64
+		expected float64
+	}{
+		{[][]float64{{5}}, 5},
+		{[][]float64{{1, 2}, {3, 4}}, -2},
+		{[][]float64{{0, 1}, {1, 0}}, -1}, // needs a row swap
+		{[][]float64{{2, -3, 1}, {2, 0, -1}, {1, 4, 5}}, 49},
+		{[][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}, 0},
+		{[][]float64{{0, 0}, {0, 0}}, 0},
+		// Wide-range diagonals are far from singular.
+		{[][]float64{{1e13, 0}, {0, 1}}, 1e13},
+		{[][]float64{{1, 0}, {0, 1e-13}}, 1e-13},
+		{[][]float64{{1e-20, 0, 0}, {0, 1e20, 0}, {0, 0, 3}}, 3},
+	}
+
+	for _, test := range tests {
+		m := mustRows(t, test.rows)
+		result, err := m.Determinant()
+		tolerance := 1e-9
+		if test.expected != 0 {
+			tolerance *= math.Abs(test.expected)
+		}
+		if err != nil || math.Abs(result-test.expected) > tolerance {
+			t.Errorf("Determinant(%v) return %v, %v, want %v", test.rows, result, err, test.expected)
+		}
+	}
+
+	wide := mustRows(t, [][]int64{{10000000000000, 0}, {0, 1}})
+	if result, err := wide.Determinant(); err != nil || result != 1e13 {
+		t.Errorf("Determinant(diag(1e13, 1)) return %v, %v, want 1e13", result, err)
+	}
+}
+
+func TestInverse(t *testing.T) {
+	tests := [][][]int{
+		{{4, 7}, {2, 6}},
+		{{0, 1}, {1, 0}},
+		{{2, -3, 1}, {2, 0, -1}, {1, 4, 5}},
+		{{0, 2, 1, 0}, {1, 0, 0, 3}, {0, 0, 4, 1}, {5, 1, 0, 0}},
+	}
+
+	for _, rows := range tests {
+		checkInverse(t, mustRows(t, rows).float64s())
+	}
+
+	// Wide-range diagonals are far from singular.
+	wide := [][][]float64{
+		{{1e13, 0}, {0, 1}},
+		{{1, 0}, {0, 1e-13}},
+		{{1e-20, 0, 0}, {0, 1e20, 0}, {0, 0, 3}},
+	}
+	for _, rows := range wide {
+		checkInverse(t, mustRows(t, rows))
+	}
+	checkInverse(t, mustRows(t, [][]int64{{10000000000000, 0}, {0, 1}}).float64s())
+
+	for _, rows := range [][][]int{{{1, 2}, {2, 4}}, {{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}} {
+		if _, err := mustRows(t, rows).Inverse(); !errors.Is(err, ErrSingular) {
+			t.Errorf("Inverse(%v) error %v, want ErrSingular", rows, err)
+		}
+	}
+}
+
+// checkInverse checks that m has an inverse and that m × Inverse(m) is the identity.
+func checkInverse(t *testing.T, m Matrix[float64]) {
+	t.Helper()
+	inv, err := m.Inverse()
+	if err != nil {
+		t.Errorf("Inverse(%v) error %v", m, err)
+		return
+	}
+
+	product, _ := m.Multiply(inv)
+	identity := Identity[float64](m.Rows())
+	for i := 0; i < m.Rows(); i++ {
+		for j := 0; j < m.Cols(); j++ {
+			if math.Abs(product.At(i, j)-identity.At(i, j)) > 1e-9 {
+				t.Errorf("Inverse(%v) × m return\n%v, want identity", m, product)
+				return
+			}
+		}
+	}
+}
+
+func benchmarkMatrix(n int) Matrix[float64] {
+	m := NewMatrix[float64](n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			m.Set(i, j, float64((i*31+j*17)%13))
+		}
+	}
+	return m
+}
+
+func BenchmarkMultiply(b *testing.B) {
+	m := benchmarkMatrix(200)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Multiply(m)
+	}
+}
+
+func BenchmarkMultiplyParallel(b *testing.B) {
+	m := benchmarkMatrix(200)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.MultiplyParallel(m, 4)
+	}
+}
+
+func BenchmarkInverse(b *testing.B) {
+	m := benchmarkMatrix(100).Clone()
+	for i := 0; i < 100; i++ {
+		m.Set(i, i, 1000) // diagonally dominant, so invertible
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Inverse()
+	}
+}
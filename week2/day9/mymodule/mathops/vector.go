@@ -0,0 +1,55 @@
+package mathops
+
+import (
+	"fmt"
+
+	"execise/mathutils"
+)
+
+// Vector is a vector of numbers.
+type Vector[T mathutils.Number] []T
+
+// Add returns the element-wise sum v + w.
+func (v Vector[T]) Add(w Vector[T]) (Vector[T], error) {
+	if len(v) != len(w) {
+		return nil, fmt.Errorf("add vectors of length %d and %d: %w", len(v), len(w), ErrDimensionMismatch)
+	}
+	sum := make(Vector[T], len(v))
+	for i := range v {
+		sum[i] = v[i] + w[i]
+	}
+	return sum, nil
+}
+
+// Scale returns v multiplied by k.
+func (v Vector[T]) Scale(k T) Vector[T] {
+	scaled := make(Vector[T], len(v))
+	for i := range v {
+		scaled[i] = v[i] * k
+	}
+	return scaled
+}
+
+// Dot returns the dot product of v and w.
+func (v Vector[T]) Dot(w Vector[T]) (T, error) {
+	if len(v) != len(w) {
+		return 0, fmt.Errorf("dot product of vectors of length %d and %d: %w", len(v), len(w), ErrDimensionMismatch)
+	}
+	var sum T
+	for i := range v {
+		sum += v[i] * w[i]
+	}
+	return sum, nil
+}
+
+// Cross returns the cross product v × w. Both vectors must have length 3.
+func (v Vector[T]) Cross(w Vector[T]) (Vector[T], error) {
+	if len(v) != 3 || len(w) != 3 {
+		return nil, fmt.Errorf("cross product of vectors of length %d and %d: %w", len(v), len(w), ErrDimensionMismatch)
+	}
+	return Vector[T]{
+		v[1]*w[2] - v[2]*w[1],
+		v[2]*w[0] - v[0]*w[2],
+		v[0]*w[1] - v[1]*w[0],
+	}, nil
+}
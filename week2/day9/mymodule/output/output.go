@@ -0,0 +1,167 @@
+// Package output gives our command line tools one consistent, colored look.
+// It wraps github.com/fatih/color with semantic styles and decides whether to
+// color from a Mode (the --color flag), the NO_COLOR environment variable
+// (https://no-color.org) and whether the output is a terminal.
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-colorable"
+	"github.com/mattn/go-isatty"
+)
+
+// Mode selects when output is colored. It implements flag.Value, so a command
+// can register it with flag.Var(&mode, "color", output.FlagUsage).
+type Mode int
+
+const (
+	Auto   Mode = iota // color terminals unless NO_COLOR is set or TERM is dumb
+	Always             // always color, even when piped
+	Never              // never color
+)
+
+// FlagUsage is the usage text for a --color flag.
+const FlagUsage = "colorize output: auto, always or never"
+
+var modeNames = []string{Auto: "auto", Always: "always", Never: "never"}
+
+func (m Mode) String() string {
+	if m < 0 || int(m) >= len(modeNames) {
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+	return modeNames[m]
+}
+
+// Set parses "auto", "always" or "never" into m.
+func (m *Mode) Set(s string) error {
+	for i, name := range modeNames {
+		if s == name {
+			*m = Mode(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid color mode %q: want auto, always or never", s)
+}
+
+// Style is the meaning of a piece of output; the Theme maps it to colors.
+type Style int
+
+const (
+	Plain     Style = iota // normal text, never colored
+	Success                // an operation completed
+	Warning                // something worth attention that is not a failure
+	Error                  // an operation failed
+	Muted                  // secondary details such as counts or context lines
+	Highlight              // the interesting part of a line, e.g. a search match
+)
+
+// Theme maps styles to color attributes. Styles missing from a Theme are printed plain.
+type Theme map[Style][]color.Attribute
+
+// DefaultTheme is the theme used by New.
+var DefaultTheme = Theme{
+	Success:   {color.FgGreen},
+	Warning:   {color.FgYellow},
+	Error:     {color.FgRed, color.Bold},
+	Muted:     {color.FgHiBlack},
+	Highlight: {color.FgRed, color.Bold},
+}
+
+// Printer writes styled text to a writer.
+type Printer struct {
+	w       io.Writer
+	enabled bool
+	colors  map[Style]*color.Color
+}
+
+// New returns a Printer writing to w with DefaultTheme.
+// Whether it colors is decided once, by Enabled(w, mode).
+func New(w io.Writer, mode Mode) *Printer {
+	p := &Printer{w: w, enabled: Enabled(w, mode)}
+	if f, ok := w.(*os.File); ok && p.enabled {
+		// Translates escape sequences for legacy Windows consoles; a no-op elsewhere.
+		p.w = colorable.NewColorable(f)
+	}
+	p.SetTheme(DefaultTheme)
+	return p
+}
+
+// Enabled reports whether output to w should be colored in the given mode.
+// In Auto mode that is the case when w is a terminal, NO_COLOR is empty and
+// TERM is not "dumb".
+func Enabled(w io.Writer, mode Mode) bool {
+	switch mode {
+	case Always:
+		return true
+	case Never:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	return ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
+
+// SetTheme replaces the colors of p.
+func (p *Printer) SetTheme(theme Theme) {
+	p.colors = make(map[Style]*color.Color, len(theme))
+	for style, attrs := range theme {
+		c := color.New(attrs...)
+		if p.enabled {
+			c.EnableColor()
+		} else {
+			c.DisableColor()
+		}
+		p.colors[style] = c
+	}
+}
+
+// Enabled reports whether p colors its output.
+func (p *Printer) Enabled() bool {
+	return p.enabled
+}
+
+// Sprint formats a like fmt.Sprint and colors the result with style.
+func (p *Printer) Sprint(style Style, a ...any) string {
+	s := fmt.Sprint(a...)
+	if c, ok := p.colors[style]; ok && style != Plain && s != "" {
+		return c.Sprint(s)
+	}
+	return s
+}
+
+// Sprintf formats like fmt.Sprintf and colors the result with style.
+func (p *Printer) Sprintf(style Style, format string, a ...any) string {
+	return p.Sprint(style, fmt.Sprintf(format, a...))
+}
+
+// Printf formats like fmt.Printf and writes the result with style.
+// Trailing newlines are written uncolored, so a style never bleeds into the next line.
+func (p *Printer) Printf(style Style, format string, a ...any) {
+	s := fmt.Sprintf(format, a...)
+	text := strings.TrimRight(s, "\n")
+	fmt.Fprint(p.w, p.Sprint(style, text)+s[len(text):])
+}
+
+// Println formats like fmt.Println and writes the result with style.
+func (p *Printer) Println(style Style, a ...any) {
+	p.Printf(style, "%s", fmt.Sprintln(a...))
+}
+
+// Success prints a line in the Success style.
+func (p *Printer) Success(a ...any) { p.Println(Success, a...) }
+
+// Warning prints a line in the Warning style.
+func (p *Printer) Warning(a ...any) { p.Println(Warning, a...) }
+
+// Error prints a line in the Error style.
+func (p *Printer) Error(a ...any) { p.Println(Error, a...) }
+
+// Muted prints a line in the Muted style.
+func (p *Printer) Muted(a ...any) { p.Println(Muted, a...) }
@@ -0,0 +1,95 @@
+package output
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestModeFlag(t *testing.T) {
+	tests := []struct {
+		arg      string
+		expected Mode
+		wantErr  bool
+	}{
+		{"--color=auto", Auto, false},
+		{"--color=always", Always, false},
+		{"-color=never", Never, false},
+		{"--color=sometimes", Auto, true},
+	}
+
+	for _, test := range tests {
+		var mode Mode
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(&bytes.Buffer{})
+		fs.Var(&mode, "color", FlagUsage)
+		err := fs.Parse([]string{test.arg})
+		if (err != nil) != test.wantErr || mode != test.expected {
+			t.Errorf("Parse(%q) = %v, %v; want %v, error %v", test.arg, mode, err, test.expected, test.wantErr)
+		}
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+
+	tests := []struct {
+		name     string
+		noColor  string
+		mode     Mode
+		file     bool
+		expected bool
+	}{
+		{"always wins over NO_COLOR", "1", Always, false, true},
+		{"never", "", Never, false, false},
+		{"auto on a buffer", "", Auto, false, false},
+		{"auto on a non-terminal file", "", Auto, true, false},
+		{"auto with NO_COLOR", "1", Auto, true, false},
+	}
+
+	for _, test := range tests {
+		t.Setenv("NO_COLOR", test.noColor)
+		var w io.Writer = &bytes.Buffer{}
+		if test.file {
+			w = devNull
+		}
+		if result := Enabled(w, test.mode); result != test.expected {
+			t.Errorf("%s: Enabled = %v; want %v", test.name, result, test.expected)
+		}
+	}
+}
+
+func TestPrinter(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(&buf, Never)
+	p.Success("saved", 3, "tasks")
+	p.Printf(Error, "failed: %d\n", 1)
+	if got, want := buf.String(), "saved 3 tasks\nfailed: 1\n"; got != want {
+		t.Errorf("Never output = %q; want %q", got, want)
+	}
+
+	buf.Reset()
+	p = New(&buf, Always)
+	p.Error("boom")
+	p.Println(Plain, "plain")
+	p.Printf(Success, "%d done\n\n", 2)
+	if got, want := buf.String(), "\x1b[31;1mboom\x1b[0;22m\nplain\n\x1b[32m2 done\x1b[0m\n\n"; got != want {
+		t.Errorf("Always output = %q; want %q", got, want)
+	}
+
+	p.SetTheme(Theme{Error: {color.FgBlue}})
+	if got, want := p.Sprint(Error, "x"), "\x1b[34mx\x1b[0m"; got != want {
+		t.Errorf("Sprint with custom theme = %q; want %q", got, want)
+	}
+	if got := p.Sprint(Warning, "x"); got != "x" {
+		t.Errorf("Sprint of a style missing from the theme = %q; want %q", got, "x")
+	}
+}